@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -17,24 +18,25 @@ var _ = (*executableDataMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (e ExecutableData) MarshalJSON() ([]byte, error) {
 	type ExecutableData struct {
-		ParentHash      common.Hash         `json:"parentHash"    gencodec:"required"`
-		FeeRecipient    common.Address      `json:"feeRecipient"  gencodec:"required"`
-		StateRoot       common.Hash         `json:"stateRoot"     gencodec:"required"`
-		ReceiptsRoot    common.Hash         `json:"receiptsRoot"  gencodec:"required"`
-		LogsBloom       hexutil.Bytes       `json:"logsBloom"     gencodec:"required"`
-		Random          common.Hash         `json:"prevRandao"    gencodec:"required"`
-		Number          hexutil.Uint64      `json:"blockNumber"   gencodec:"required"`
-		GasLimit        hexutil.Uint64      `json:"gasLimit"      gencodec:"required"`
-		GasUsed         hexutil.Uint64      `json:"gasUsed"       gencodec:"required"`
-		Timestamp       hexutil.Uint64      `json:"timestamp"     gencodec:"required"`
-		ExtraData       hexutil.Bytes       `json:"extraData"     gencodec:"required"`
-		BaseFeePerGas   *hexutil.Big        `json:"baseFeePerGas" gencodec:"required"`
-		BlockHash       common.Hash         `json:"blockHash"     gencodec:"required"`
-		Transactions    []hexutil.Bytes     `json:"transactions"`
-		Withdrawals     []*types.Withdrawal `json:"withdrawals"`
-		TxHash          common.Hash         `json:"txHash"`
-		WithdrawalsHash common.Hash         `json:"withdrawalsHash"`
-		TaikoBlock      bool
+		ParentHash          common.Hash         `json:"parentHash"    gencodec:"required"`
+		FeeRecipient        common.Address      `json:"feeRecipient"  gencodec:"required"`
+		StateRoot           common.Hash         `json:"stateRoot"     gencodec:"required"`
+		ReceiptsRoot        common.Hash         `json:"receiptsRoot"  gencodec:"required"`
+		LogsBloom           hexutil.Bytes       `json:"logsBloom"     gencodec:"required"`
+		Random              common.Hash         `json:"prevRandao"    gencodec:"required"`
+		Number              hexutil.Uint64      `json:"blockNumber"   gencodec:"required"`
+		GasLimit            hexutil.Uint64      `json:"gasLimit"      gencodec:"required"`
+		GasUsed             hexutil.Uint64      `json:"gasUsed"       gencodec:"required"`
+		Timestamp           hexutil.Uint64      `json:"timestamp"     gencodec:"required"`
+		ExtraData           hexutil.Bytes       `json:"extraData"     gencodec:"required"`
+		BaseFeePerGas       *hexutil.Big        `json:"baseFeePerGas" gencodec:"required"`
+		BlockHash           common.Hash         `json:"blockHash"     gencodec:"required"`
+		Transactions        []hexutil.Bytes     `json:"transactions"`
+		Withdrawals         []*types.Withdrawal `json:"withdrawals"`
+		TxHash              common.Hash         `json:"txHash"`
+		WithdrawalsHash     common.Hash         `json:"withdrawalsHash"`
+		TaikoBlock          bool
+		SkippedTransactions []*rawdb.SkippedTransaction `json:"skippedTransactions,omitempty"`
 	}
 	var enc ExecutableData
 	enc.ParentHash = e.ParentHash
@@ -60,30 +62,32 @@ func (e ExecutableData) MarshalJSON() ([]byte, error) {
 	enc.TxHash = e.TxHash
 	enc.WithdrawalsHash = e.WithdrawalsHash
 	enc.TaikoBlock = e.TaikoBlock
+	enc.SkippedTransactions = e.SkippedTransactions
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (e *ExecutableData) UnmarshalJSON(input []byte) error {
 	type ExecutableData struct {
-		ParentHash      *common.Hash        `json:"parentHash"    gencodec:"required"`
-		FeeRecipient    *common.Address     `json:"feeRecipient"  gencodec:"required"`
-		StateRoot       *common.Hash        `json:"stateRoot"     gencodec:"required"`
-		ReceiptsRoot    *common.Hash        `json:"receiptsRoot"  gencodec:"required"`
-		LogsBloom       *hexutil.Bytes      `json:"logsBloom"     gencodec:"required"`
-		Random          *common.Hash        `json:"prevRandao"    gencodec:"required"`
-		Number          *hexutil.Uint64     `json:"blockNumber"   gencodec:"required"`
-		GasLimit        *hexutil.Uint64     `json:"gasLimit"      gencodec:"required"`
-		GasUsed         *hexutil.Uint64     `json:"gasUsed"       gencodec:"required"`
-		Timestamp       *hexutil.Uint64     `json:"timestamp"     gencodec:"required"`
-		ExtraData       *hexutil.Bytes      `json:"extraData"     gencodec:"required"`
-		BaseFeePerGas   *hexutil.Big        `json:"baseFeePerGas" gencodec:"required"`
-		BlockHash       *common.Hash        `json:"blockHash"     gencodec:"required"`
-		Transactions    []hexutil.Bytes     `json:"transactions"`
-		Withdrawals     []*types.Withdrawal `json:"withdrawals"`
-		TxHash          *common.Hash        `json:"txHash"`
-		WithdrawalsHash *common.Hash        `json:"withdrawalsHash"`
-		TaikoBlock      *bool
+		ParentHash          *common.Hash        `json:"parentHash"    gencodec:"required"`
+		FeeRecipient        *common.Address     `json:"feeRecipient"  gencodec:"required"`
+		StateRoot           *common.Hash        `json:"stateRoot"     gencodec:"required"`
+		ReceiptsRoot        *common.Hash        `json:"receiptsRoot"  gencodec:"required"`
+		LogsBloom           *hexutil.Bytes      `json:"logsBloom"     gencodec:"required"`
+		Random              *common.Hash        `json:"prevRandao"    gencodec:"required"`
+		Number              *hexutil.Uint64     `json:"blockNumber"   gencodec:"required"`
+		GasLimit            *hexutil.Uint64     `json:"gasLimit"      gencodec:"required"`
+		GasUsed             *hexutil.Uint64     `json:"gasUsed"       gencodec:"required"`
+		Timestamp           *hexutil.Uint64     `json:"timestamp"     gencodec:"required"`
+		ExtraData           *hexutil.Bytes      `json:"extraData"     gencodec:"required"`
+		BaseFeePerGas       *hexutil.Big        `json:"baseFeePerGas" gencodec:"required"`
+		BlockHash           *common.Hash        `json:"blockHash"     gencodec:"required"`
+		Transactions        []hexutil.Bytes     `json:"transactions"`
+		Withdrawals         []*types.Withdrawal `json:"withdrawals"`
+		TxHash              *common.Hash        `json:"txHash"`
+		WithdrawalsHash     *common.Hash        `json:"withdrawalsHash"`
+		TaikoBlock          *bool
+		SkippedTransactions []*rawdb.SkippedTransaction `json:"skippedTransactions,omitempty"`
 	}
 	var dec ExecutableData
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -159,5 +163,8 @@ func (e *ExecutableData) UnmarshalJSON(input []byte) error {
 	if dec.TaikoBlock != nil {
 		e.TaikoBlock = *dec.TaikoBlock
 	}
+	if dec.SkippedTransactions != nil {
+		e.SkippedTransactions = dec.SkippedTransactions
+	}
 	return nil
 }
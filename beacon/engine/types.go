@@ -93,6 +93,10 @@ type ExecutableData struct {
 	TxHash          common.Hash         `json:"txHash"`          // CHANGE(taiko): allow passing txHash directly instead of transactions list
 	WithdrawalsHash common.Hash         `json:"withdrawalsHash"` // CHANGE(taiko): allow passing WithdrawalsHash directly instead of withdrawals
 	TaikoBlock      bool                // CHANGE(taiko): whether this is a Taiko L2 block, only used by ExecutableDataToBlock
+
+	// CHANGE(taiko): transactions in the txList skipped while sealing the block,
+	// only set by engine_getPayload.
+	SkippedTransactions []*rawdb.SkippedTransaction `json:"skippedTransactions,omitempty"`
 }
 
 // JSON type overrides for executableData.
@@ -125,6 +129,15 @@ type PayloadStatusV1 struct {
 	ValidationError *string      `json:"validationError"`
 }
 
+// CHANGE(taiko): TaikoBlockStatusV1 is the status of a L2 block built by a
+// `engine_insertTaikoBlocksV1` call.
+type TaikoBlockStatusV1 struct {
+	Status              string                      `json:"status"`
+	BlockHash           *common.Hash                `json:"blockHash"`
+	SkippedTransactions []*rawdb.SkippedTransaction `json:"skippedTransactions"`
+	ValidationError     *string                     `json:"validationError"`
+}
+
 type TransitionConfigurationV1 struct {
 	TerminalTotalDifficulty *hexutil.Big   `json:"terminalTotalDifficulty"`
 	TerminalBlockHash       common.Hash    `json:"terminalBlockHash"`
@@ -0,0 +1,257 @@
+package t8ntool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// taikoEnv is the environment of the replayed block when its parent state is
+// given as an alloc.
+type taikoEnv struct {
+	BlockHashes map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
+}
+
+// TaikoPrestate is the parent state of a replayed Taiko block, together with the
+// payload attributes the block is built with.
+//
+// The BLOCKHASH opcode is resolved from the headers of the chain database if
+// there's one, or from the given block hashes otherwise.
+type TaikoPrestate struct {
+	State       *state.StateDB
+	Payload     *engine.PayloadAttributes
+	ParentHash  common.Hash
+	BlockHashes map[math.HexOrDecimal64]common.Hash
+	Chain       *taikoChain
+}
+
+// TaikoExecutionResult is the result of replaying a Taiko block.
+type TaikoExecutionResult struct {
+	ExecutionResult
+	BlockHash common.Hash                 `json:"blockHash"`
+	Skipped   []*rawdb.SkippedTransaction `json:"skipped"`
+}
+
+// Apply builds the Taiko block described by the payload attributes on top of the
+// prestate, using the same rules as the worker when it seals a proposed block:
+// the first transaction is the anchor transaction, the invalid transactions of
+// the txList are skipped instead of invalidating the block, the base fee is
+// credited to the treasury and the withdrawals are hashed the Taiko way.
+func (pre *TaikoPrestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
+	getTracerFn func(txIndex int, txHash common.Hash) (tracer vm.EVMLogger, err error)) (*types.Block, *TaikoExecutionResult, error) {
+	var (
+		attrs = pre.Payload
+		meta  = attrs.BlockMetadata
+	)
+	txs, dropped, err := core.DecodeTxList(chainConfig, meta.TxList)
+	if err != nil {
+		return nil, nil, NewError(ErrorRlp, fmt.Errorf("failed to decode txList: %v", err))
+	}
+
+	withdrawalsHash := types.CalcWithdrawalsRootTaiko(attrs.Withdrawals)
+	header := &types.Header{
+		ParentHash:      pre.ParentHash,
+		UncleHash:       types.EmptyUncleHash,
+		Coinbase:        meta.Beneficiary,
+		Difficulty:      common.Big0,
+		Number:          new(big.Int).Set(attrs.L1Origin.BlockID),
+		GasLimit:        meta.GasLimit,
+		Time:            attrs.Timestamp,
+		Extra:           meta.ExtraData,
+		MixDigest:       meta.MixHash,
+		BaseFee:         attrs.BaseFeePerGas,
+		WithdrawalsHash: &withdrawalsHash,
+	}
+
+	var (
+		statedb   = pre.State
+		signer    = types.MakeSigner(chainConfig, header.Number)
+		rules     = chainConfig.Rules(header.Number, true, header.Time)
+		gaspool   = new(core.GasPool).AddGas(header.GasLimit)
+		vmContext = core.NewEVMBlockContext(header, pre.Chain, &meta.Beneficiary)
+		included  types.Transactions
+		receipts  = make(types.Receipts, 0)
+		skipped   = append(make([]*rawdb.SkippedTransaction, 0), dropped...)
+	)
+	var hashError error
+	if pre.Chain.db != nil {
+		vmContext.GetHash = core.GetHashFn(header, pre.Chain)
+	} else {
+		vmContext.GetHash = func(num uint64) common.Hash {
+			h, ok := pre.BlockHashes[math.HexOrDecimal64(num)]
+			if !ok {
+				hashError = fmt.Errorf("getHash(%d) invoked, blockhash for that block not provided", num)
+			}
+			return h
+		}
+	}
+
+	skip := func(i int, tx *types.Transaction, err error) {
+		log.Info("Skip an invalid proposed transaction", "index", i, "hash", tx.Hash(), "reason", err)
+		skipped = append(skipped, &rawdb.SkippedTransaction{Index: uint64(i), Hash: tx.Hash(), Reason: err.Error()})
+	}
+	for i, tx := range txs {
+		sender, err := types.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+		if err != nil {
+			skip(i, tx, err)
+			continue
+		}
+		if i != 0 && sender == chainConfig.TaikoParams().AnchorSender {
+			skip(i, tx, taiko.ErrAnchorTxNotFirst)
+			continue
+		}
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee, i == 0)
+		if err != nil {
+			skip(i, tx, err)
+			continue
+		}
+		tracer, err := getTracerFn(len(included), tx.Hash())
+		if err != nil {
+			return nil, nil, err
+		}
+		vmConfig.Tracer = tracer
+		vmConfig.Debug = (tracer != nil)
+
+		statedb.Prepare(rules, sender, meta.Beneficiary, tx.To(), vm.ActivePrecompiles(rules), tx.AccessList())
+		statedb.SetTxContext(tx.Hash(), len(included))
+
+		var (
+			snapshot = statedb.Snapshot()
+			prevGas  = gaspool.Gas()
+			evm      = vm.NewEVM(vmContext, core.NewEVMTxContext(msg), statedb, chainConfig, vmConfig)
+		)
+		result, err := core.ApplyMessage(evm, msg, gaspool)
+		if err != nil {
+			statedb.RevertToSnapshot(snapshot)
+			gaspool.SetGas(prevGas)
+			skip(i, tx, err)
+			continue
+		}
+		if hashError != nil {
+			return nil, nil, NewError(ErrorMissingBlockhash, hashError)
+		}
+		statedb.Finalise(true)
+		header.GasUsed += result.UsedGas
+
+		receipt := &types.Receipt{Type: tx.Type(), CumulativeGasUsed: header.GasUsed}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+		} else {
+			receipt.Status = types.ReceiptStatusSuccessful
+		}
+		receipt.TxHash = tx.Hash()
+		receipt.GasUsed = result.UsedGas
+		if msg.To == nil {
+			receipt.ContractAddress = crypto.CreateAddress(msg.From, tx.Nonce())
+		}
+		receipt.Logs = statedb.GetLogs(tx.Hash(), header.Number.Uint64(), common.Hash{})
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receipt.TransactionIndex = uint(len(included))
+
+		included = append(included, tx)
+		receipts = append(receipts, receipt)
+	}
+
+	block, err := taiko.New(nil).FinalizeAndAssemble(pre.Chain, header, statedb, included, nil, receipts, attrs.Withdrawals)
+	if err != nil {
+		return nil, nil, NewError(ErrorEVM, fmt.Errorf("could not assemble block: %v", err))
+	}
+	root, err := statedb.Commit(true)
+	if err != nil {
+		return nil, nil, NewError(ErrorEVM, fmt.Errorf("could not commit state: %v", err))
+	}
+	// The receipts were created before the block hash was known.
+	for _, receipt := range receipts {
+		receipt.BlockHash = block.Hash()
+		receipt.BlockNumber = block.Number()
+		for _, l := range receipt.Logs {
+			l.BlockHash = block.Hash()
+		}
+	}
+
+	execRs := &TaikoExecutionResult{
+		ExecutionResult: ExecutionResult{
+			StateRoot:       root,
+			TxRoot:          block.TxHash(),
+			ReceiptRoot:     block.ReceiptHash(),
+			LogsHash:        rlpHash(statedb.Logs()),
+			Bloom:           block.Bloom(),
+			Receipts:        receipts,
+			Difficulty:      (*math.HexOrDecimal256)(block.Difficulty()),
+			GasUsed:         (math.HexOrDecimal64)(block.GasUsed()),
+			BaseFee:         (*math.HexOrDecimal256)(block.BaseFee()),
+			WithdrawalsRoot: block.Header().WithdrawalsHash,
+		},
+		BlockHash: block.Hash(),
+		Skipped:   skipped,
+	}
+	return block, execRs, nil
+}
+
+// taikoChain gives the Taiko consensus engine access to the chain config and the
+// headers of the node database, if the block is replayed on top of a datadir.
+type taikoChain struct {
+	config *params.ChainConfig
+	db     ethdb.Reader
+}
+
+var (
+	_ consensus.ChainHeaderReader = (*taikoChain)(nil)
+	_ core.ChainContext           = (*taikoChain)(nil)
+)
+
+func (c *taikoChain) Config() *params.ChainConfig { return c.config }
+func (c *taikoChain) Engine() consensus.Engine    { return taiko.New(nil) }
+
+func (c *taikoChain) CurrentHeader() *types.Header {
+	if c.db == nil {
+		return nil
+	}
+	return rawdb.ReadHeadHeader(c.db)
+}
+
+func (c *taikoChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if c.db == nil {
+		return nil
+	}
+	return rawdb.ReadHeader(c.db, hash, number)
+}
+
+func (c *taikoChain) GetHeaderByNumber(number uint64) *types.Header {
+	if c.db == nil {
+		return nil
+	}
+	return c.GetHeader(rawdb.ReadCanonicalHash(c.db, number), number)
+}
+
+func (c *taikoChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if c.db == nil {
+		return nil
+	}
+	number := rawdb.ReadHeaderNumber(c.db, hash)
+	if number == nil {
+		return nil
+	}
+	return c.GetHeader(hash, *number)
+}
+
+func (c *taikoChain) GetTd(hash common.Hash, number uint64) *big.Int {
+	if c.db == nil {
+		return nil
+	}
+	return rawdb.ReadTd(c.db, hash, number)
+}
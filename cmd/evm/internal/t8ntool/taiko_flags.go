@@ -0,0 +1,25 @@
+package t8ntool
+
+import (
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	InputPayloadFlag = &cli.StringFlag{
+		Name: "input.payload",
+		Usage: "`stdin` or file name of where to find the payload attributes the Taiko block " +
+			"is built with, including the block metadata, base fee and withdrawals.",
+		Value: "payload.json",
+	}
+	InputDatadirFlag = &cli.StringFlag{
+		Name: "input.datadir",
+		Usage: "Data directory of a Taiko node to replay the block on top of, instead of an alloc. " +
+			"The parent block is the canonical block preceding the replayed one.",
+	}
+	TaikoNetworkFlag = &cli.StringFlag{
+		Name:  "taiko.network",
+		Usage: "Name of the Taiko network whose chain config is used with an alloc",
+		Value: core.DefaultTaikoNetwork,
+	}
+)
@@ -0,0 +1,184 @@
+package t8ntool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+)
+
+type taikoInput struct {
+	Alloc   core.GenesisAlloc         `json:"alloc,omitempty"`
+	Env     *taikoEnv                 `json:"env,omitempty"`
+	Payload *engine.PayloadAttributes `json:"payload,omitempty"`
+}
+
+// TaikoTransition replays the building of a Taiko L2 block from its payload
+// attributes, on top of a parent state given either as an alloc or as the
+// database of a Taiko node.
+func TaikoTransition(ctx *cli.Context) error {
+	// Configure the go-ethereum logger
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+	glogger.Verbosity(log.Lvl(ctx.Int(VerbosityFlag.Name)))
+	log.Root().SetHandler(glogger)
+
+	baseDir, err := createBasedir(ctx)
+	if err != nil {
+		return NewError(ErrorIO, fmt.Errorf("failed creating output basedir: %v", err))
+	}
+	getTracer := func(txIndex int, txHash common.Hash) (vm.EVMLogger, error) {
+		return nil, nil
+	}
+	if ctx.Bool(TraceFlag.Name) {
+		logConfig := &logger.Config{
+			DisableStack:     ctx.Bool(TraceDisableStackFlag.Name),
+			EnableMemory:     ctx.Bool(TraceEnableMemoryFlag.Name),
+			EnableReturnData: ctx.Bool(TraceEnableReturnDataFlag.Name),
+			Debug:            true,
+		}
+		var prevFile *os.File
+		defer func() {
+			if prevFile != nil {
+				prevFile.Close()
+			}
+		}()
+		getTracer = func(txIndex int, txHash common.Hash) (vm.EVMLogger, error) {
+			if prevFile != nil {
+				prevFile.Close()
+			}
+			traceFile, err := os.Create(path.Join(baseDir, fmt.Sprintf("trace-%d-%v.jsonl", txIndex, txHash.String())))
+			if err != nil {
+				return nil, NewError(ErrorIO, fmt.Errorf("failed creating trace-file: %v", err))
+			}
+			prevFile = traceFile
+			return logger.NewJSONLogger(logConfig, traceFile), nil
+		}
+	}
+
+	var (
+		datadir    = ctx.String(InputDatadirFlag.Name)
+		allocStr   = ctx.String(InputAllocFlag.Name)
+		envStr     = ctx.String(InputEnvFlag.Name)
+		payloadStr = ctx.String(InputPayloadFlag.Name)
+		inputData  = &taikoInput{}
+	)
+	if datadir != "" {
+		allocStr, envStr = "", ""
+	} else if !ctx.IsSet(InputEnvFlag.Name) {
+		// The block hashes are only needed if the block uses BLOCKHASH.
+		envStr = ""
+	}
+	if allocStr == stdinSelector || envStr == stdinSelector || payloadStr == stdinSelector {
+		decoder := json.NewDecoder(os.Stdin)
+		if err := decoder.Decode(inputData); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling stdin: %v", err))
+		}
+	}
+	if allocStr != stdinSelector && allocStr != "" {
+		if err := readFile(allocStr, "alloc", &inputData.Alloc); err != nil {
+			return err
+		}
+	}
+	if envStr != stdinSelector && envStr != "" {
+		inputData.Env = new(taikoEnv)
+		if err := readFile(envStr, "env", inputData.Env); err != nil {
+			return err
+		}
+	}
+	if payloadStr != stdinSelector {
+		if err := readFile(payloadStr, "payload", &inputData.Payload); err != nil {
+			return err
+		}
+	}
+	if inputData.Payload == nil {
+		return NewError(ErrorConfig, errors.New("missing payload attributes"))
+	}
+	number := inputData.Payload.L1Origin.BlockID.Uint64()
+	if number == 0 {
+		return NewError(ErrorConfig, errors.New("the genesis block can't be replayed"))
+	}
+
+	var (
+		chainConfig *params.ChainConfig
+		prestate    = &TaikoPrestate{Payload: inputData.Payload}
+	)
+	if datadir != "" {
+		chaindata := filepath.Join(datadir, "geth", "chaindata")
+		db, err := rawdb.Open(rawdb.OpenOptions{
+			Directory:         chaindata,
+			AncientsDirectory: filepath.Join(chaindata, "ancient"),
+			Namespace:         "t8n/",
+			ReadOnly:          true,
+		})
+		if err != nil {
+			return NewError(ErrorIO, fmt.Errorf("failed opening database: %v", err))
+		}
+		defer db.Close()
+
+		if chainConfig = rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0)); chainConfig == nil {
+			return NewError(ErrorConfig, errors.New("chain config not found in database"))
+		}
+		parent := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number-1), number-1)
+		if parent == nil {
+			return NewError(ErrorConfig, fmt.Errorf("parent block #%d not found in database", number-1))
+		}
+		if prestate.State, err = state.New(parent.Root, state.NewDatabase(db), nil); err != nil {
+			return NewError(ErrorConfig, fmt.Errorf("parent state of block #%d not available: %v", number, err))
+		}
+		prestate.ParentHash = parent.Hash()
+		prestate.Chain = &taikoChain{config: chainConfig, db: db}
+	} else {
+		registry, err := core.NewTaikoNetworkRegistry()
+		if err != nil {
+			return NewError(ErrorConfig, err)
+		}
+		network, err := registry.Network(ctx.String(TaikoNetworkFlag.Name))
+		if err != nil {
+			return NewError(ErrorConfig, err)
+		}
+		chainConfig = network.Config
+
+		prestate.State = MakePreState(rawdb.NewMemoryDatabase(), inputData.Alloc)
+		if inputData.Env != nil {
+			prestate.BlockHashes = inputData.Env.BlockHashes
+			prestate.ParentHash = inputData.Env.BlockHashes[math.HexOrDecimal64(number-1)]
+		}
+		prestate.Chain = &taikoChain{config: chainConfig}
+	}
+
+	block, result, err := prestate.Apply(vm.Config{}, chainConfig, getTracer)
+	if err != nil {
+		return err
+	}
+	// The post-state of a node database is too large to be dumped, only its root
+	// is reported in that case.
+	var collector Alloc
+	if datadir == "" {
+		collector = make(Alloc)
+		prestate.State.DumpToCollector(collector, nil)
+	}
+	body, _ := rlp.EncodeToBytes(block.Transactions())
+	if err := dispatchOutput(ctx, baseDir, result, collector, hexutil.Bytes(body)); err != nil {
+		return err
+	}
+	if ctx.String(OutputBlockFlag.Name) == "" {
+		return nil
+	}
+	return dispatchBlock(ctx, baseDir, block)
+}
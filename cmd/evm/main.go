@@ -153,6 +153,31 @@ var stateTransitionCommand = &cli.Command{
 	},
 }
 
+// CHANGE(taiko): replay the building of a Taiko L2 block.
+var taikoTransitionCommand = &cli.Command{
+	Name:    "taiko-transition",
+	Aliases: []string{"taiko-t8n"},
+	Usage:   "replays the building of a Taiko block from its payload attributes",
+	Action:  t8ntool.TaikoTransition,
+	Flags: []cli.Flag{
+		t8ntool.TraceFlag,
+		t8ntool.TraceEnableMemoryFlag,
+		t8ntool.TraceDisableStackFlag,
+		t8ntool.TraceEnableReturnDataFlag,
+		t8ntool.OutputBasedir,
+		t8ntool.OutputAllocFlag,
+		t8ntool.OutputResultFlag,
+		t8ntool.OutputBodyFlag,
+		t8ntool.OutputBlockFlag,
+		t8ntool.InputAllocFlag,
+		t8ntool.InputEnvFlag,
+		t8ntool.InputPayloadFlag,
+		t8ntool.InputDatadirFlag,
+		t8ntool.TaikoNetworkFlag,
+		t8ntool.VerbosityFlag,
+	},
+}
+
 var transactionCommand = &cli.Command{
 	Name:    "transaction",
 	Aliases: []string{"t9n"},
@@ -223,6 +248,7 @@ func init() {
 		stateTransitionCommand,
 		transactionCommand,
 		blockBuilderCommand,
+		taikoTransitionCommand, // CHANGE(taiko)
 	}
 }
 
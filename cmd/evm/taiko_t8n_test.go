@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/internal/cmdtest"
+)
+
+func TestTaikoT8n(t *testing.T) {
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+	for i, tc := range []struct {
+		base        string
+		args        []string
+		expExitCode int
+		expOut      string
+	}{
+		{ // Replay a block with skipped transactions and withdrawals
+			base:   "./testdata/taiko",
+			args:   []string{"--input.alloc", "alloc.json", "--input.env", "env.json", "--input.payload", "payload.json"},
+			expOut: "exp.json",
+		},
+		{ // Test exit (3) on unknown network
+			base:        "./testdata/taiko",
+			args:        []string{"--input.alloc", "alloc.json", "--input.payload", "payload.json", "--taiko.network", "unknown"},
+			expExitCode: 3,
+		},
+	} {
+		args := []string{"taiko-t8n", "--output.result", "stdout", "--output.alloc", "stdout", "--output.body", "", "--output.block", ""}
+		for _, arg := range tc.args {
+			if strings.HasSuffix(arg, ".json") {
+				arg = fmt.Sprintf("%v/%v", tc.base, arg)
+			}
+			args = append(args, arg)
+		}
+		tt.Logf("args: %v\n", strings.Join(args, " "))
+		tt.Run("evm-test", args...)
+		if tc.expOut != "" {
+			want, err := os.ReadFile(fmt.Sprintf("%v/%v", tc.base, tc.expOut))
+			if err != nil {
+				t.Fatalf("test %d: could not read expected output: %v", i, err)
+			}
+			have := tt.Output()
+			ok, err := cmpJson(have, want)
+			switch {
+			case err != nil:
+				t.Fatalf("test %d, json parsing failed: %v", i, err)
+			case !ok:
+				t.Fatalf("test %d: output wrong, have \n%v\nwant\n%v\n", i, string(have), string(want))
+			}
+		}
+		tt.WaitExit()
+		if have, want := tt.ExitStatus(), tc.expExitCode; have != want {
+			t.Fatalf("test %d: wrong exit code, have %d, want %d", i, have, want)
+		}
+	}
+}
@@ -69,6 +69,8 @@ Remove blockchain and state databases`,
 			dbExportCmd,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
+			// CHANGE(taiko): L1Origin consistency check.
+			dbCheckL1OriginCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -251,7 +251,8 @@ func init() {
 	)
 
 	// CHANGE(taiko): append Taiko flags into the original GETH flags
-	app.Flags = append(app.Flags, &utils.TaikoFlag)
+	app.Flags = append(app.Flags, &utils.TaikoFlag, &utils.TaikoNetworkFlag, &utils.TaikoNetworksDirFlag, &utils.TaikoDepositIndexFlag, &utils.TaikoTxLanesFlag,
+		&utils.TaikoTxPoolMinTxGasLimitFlag, &utils.TaikoTxPoolBlockMaxGasLimitFlag, &utils.TaikoTxPoolMaxBytesPerTxListFlag, &utils.TaikoTxPoolTxListCompressionFlag)
 
 	app.Before = func(ctx *cli.Context) error {
 		flags.MigrateGlobalFlags(ctx)
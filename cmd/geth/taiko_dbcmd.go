@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	l1OriginDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only report the inconsistent L1Origin records, without repairing them",
+	}
+	dbCheckL1OriginCmd = &cli.Command{
+		Action: checkL1Origin,
+		Name:   "check-l1origin",
+		Usage:  "Verify that the L1Origin records are consistent with the canonical chain",
+		Flags: flags.Merge([]cli.Flag{
+			l1OriginDryRunFlag,
+		}, utils.NetworkFlags, utils.DatabasePathFlags),
+		Description: `This command iterates all L1Origin records in the database, and checks that
+each of them points at a canonical L2 block which is not above the chain head, and that the head
+L1Origin pointer refers to an existing record. Inconsistent records are removed and the head
+L1Origin pointer is moved to the newest remaining record, and the L1 block hash and height
+reverse indexes of the remaining records are rebuilt, unless --dry-run is set.`,
+	}
+)
+
+func checkL1Origin(ctx *cli.Context) error {
+	dryRun := ctx.Bool(l1OriginDryRunFlag.Name)
+
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, dryRun)
+	defer db.Close()
+
+	headBlock := rawdb.ReadHeadBlock(db)
+	if headBlock == nil {
+		return fmt.Errorf("head block not found")
+	}
+	var (
+		head  = headBlock.NumberU64()
+		stale []*rawdb.L1Origin
+		count int
+	)
+	err := rawdb.IterateL1Origins(db, func(l1Origin *rawdb.L1Origin) bool {
+		count++
+		if err := rawdb.VerifyL1Origin(db, l1Origin, head); err != nil {
+			fmt.Printf("Inconsistent L1Origin %v: %v\n", l1Origin.BlockID, err)
+			stale = append(stale, l1Origin)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	headID, err := rawdb.ReadHeadL1Origin(db)
+	if err != nil {
+		return err
+	}
+	headConsistent := true
+	if headID != nil {
+		if headID.Cmp(new(big.Int).SetUint64(head)) > 0 {
+			fmt.Printf("Head L1Origin %v above the chain head %d\n", headID, head)
+			headConsistent = false
+		} else if l1Origin, _ := rawdb.ReadL1Origin(db, headID); l1Origin == nil {
+			fmt.Printf("Head L1Origin %v missing\n", headID)
+			headConsistent = false
+		}
+	}
+	log.Info("Checked the L1Origin records", "head", head, "items", count, "inconsistent", len(stale), "headL1Origin", headID)
+
+	if dryRun {
+		return nil
+	}
+	if len(stale) > 0 || !headConsistent {
+		newHeadID := rawdb.RepairL1Origins(db, stale, head)
+		log.Info("Repaired the L1Origin records", "removed", len(stale), "headL1Origin", newHeadID)
+	}
+	indexed, err := rawdb.BackfillL1OriginIndexes(db)
+	if err != nil {
+		return err
+	}
+	log.Info("Rebuilt the L1Origin indexes", "items", indexed)
+
+	return nil
+}
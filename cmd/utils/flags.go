@@ -1081,6 +1081,9 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 	switch {
 	case ctx.IsSet(BootnodesFlag.Name):
 		urls = SplitAndTrim(ctx.String(BootnodesFlag.Name))
+	// CHANGE(taiko): use the bootnodes of the Taiko network definition.
+	case ctx.IsSet(TaikoFlag.Name):
+		urls = MakeTaikoNetwork(ctx, ctx.Uint64(NetworkIdFlag.Name)).Bootnodes
 	case ctx.Bool(SepoliaFlag.Name):
 		urls = params.SepoliaBootnodes
 	case ctx.Bool(RinkebyFlag.Name):
@@ -1602,6 +1605,19 @@ func setTxPool(ctx *cli.Context, cfg *txpool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	// CHANGE(taiko): set the protocol limits of the proposed transactions.
+	if ctx.IsSet(TaikoTxPoolMinTxGasLimitFlag.Name) {
+		cfg.ProtocolLimits.MinTxGasLimit = ctx.Uint64(TaikoTxPoolMinTxGasLimitFlag.Name)
+	}
+	if ctx.IsSet(TaikoTxPoolBlockMaxGasLimitFlag.Name) {
+		cfg.ProtocolLimits.BlockMaxGasLimit = ctx.Uint64(TaikoTxPoolBlockMaxGasLimitFlag.Name)
+	}
+	if ctx.IsSet(TaikoTxPoolMaxBytesPerTxListFlag.Name) {
+		cfg.ProtocolLimits.MaxBytesPerTxList = ctx.Uint64(TaikoTxPoolMaxBytesPerTxListFlag.Name)
+	}
+	if ctx.IsSet(TaikoTxPoolTxListCompressionFlag.Name) {
+		cfg.ProtocolLimits.TxListCompression = ctx.String(TaikoTxPoolTxListCompressionFlag.Name)
+	}
 }
 
 func setEthash(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1858,11 +1874,28 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		}
 	}
 
+	// CHANGE(taiko): index the L1 -> L2 deposits if requested.
+	if ctx.IsSet(TaikoDepositIndexFlag.Name) {
+		cfg.TaikoDepositIndex = ctx.Bool(TaikoDepositIndexFlag.Name)
+	}
+	// CHANGE(taiko): load the priority sender lanes if given.
+	if ctx.IsSet(TaikoTxLanesFlag.Name) {
+		lanes, err := core.LoadTxLanesConfig(ctx.String(TaikoTxLanesFlag.Name))
+		if err != nil {
+			Fatalf("%v", err)
+		}
+		cfg.TaikoTxLanes = lanes
+	}
+
 	// Override any default configs for hard coded networks.
 	switch {
 	// CHANGE(taiko): when --taiko flag is set, use the Taiko genesis.
 	case ctx.IsSet(TaikoFlag.Name):
-		cfg.Genesis = core.TaikoGenesisBlock(cfg.NetworkId)
+		network := MakeTaikoNetwork(ctx, cfg.NetworkId)
+		if ctx.IsSet(TaikoNetworkFlag.Name) && !ctx.IsSet(NetworkIdFlag.Name) {
+			cfg.NetworkId = network.Config.ChainID.Uint64()
+		}
+		cfg.Genesis = network.Genesis()
 	case ctx.Bool(MainnetFlag.Name):
 		if !ctx.IsSet(NetworkIdFlag.Name) {
 			cfg.NetworkId = 1
@@ -1,8 +1,9 @@
 package utils
 
 import (
-	"os"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/node"
@@ -16,20 +17,91 @@ var (
 		Name:  "taiko",
 		Usage: "Taiko network",
 	}
+	TaikoNetworkFlag = cli.StringFlag{
+		Name:  "taiko.network",
+		Usage: "Name of the Taiko network to join (defaults to the network matching --networkid, or Taiko mainnet)",
+	}
+	TaikoNetworksDirFlag = cli.StringFlag{
+		Name:  "taiko.networksdir",
+		Usage: "Directory of additional Taiko network definitions (*.json)",
+	}
+	TaikoDepositIndexFlag = cli.BoolFlag{
+		Name:  "taiko.depositindex",
+		Usage: "Index the L1 -> L2 ETH deposits by recipient (enables taiko_getDepositsByAddress)",
+	}
+	TaikoTxLanesFlag = cli.StringFlag{
+		Name:  "taiko.txlanes",
+		Usage: "JSON file of the priority sender lanes used by taiko_txPoolContent (editable with admin_setTaikoTxLanes)",
+	}
+	TaikoTxPoolMinTxGasLimitFlag = cli.Uint64Flag{
+		Name:  "taiko.txpool.mintxgaslimit",
+		Usage: "Minimum gas limit of a transaction accepted into the pool, as enforced by the proposer",
+	}
+	TaikoTxPoolBlockMaxGasLimitFlag = cli.Uint64Flag{
+		Name:  "taiko.txpool.blockmaxgaslimit",
+		Usage: "Maximum gas limit of a proposed block, capping the transactions accepted into the pool (0 = protocol default)",
+	}
+	TaikoTxPoolMaxBytesPerTxListFlag = cli.Uint64Flag{
+		Name:  "taiko.txpool.maxbytespertxlist",
+		Usage: "Maximum size of a proposed txList's encoded bytes, capping the transactions accepted into the pool (0 = chain default)",
+	}
+	TaikoTxPoolTxListCompressionFlag = cli.StringFlag{
+		Name:  "taiko.txpool.txlistcompression",
+		Usage: "Compression of the proposed txLists' encoded bytes, the pool's size limit applies to (none, zlib)",
+		Value: core.TxListCompressionNone,
+	}
 )
 
-// RegisterTaikoAPIs initializes and registers the Taiko RPC APIs.
+// MakeTaikoNetwork returns the Taiko network selected by the command line flags,
+// either the one given by --taiko.network, or the one matching the network ID.
+func MakeTaikoNetwork(ctx *cli.Context, networkID uint64) *core.TaikoNetwork {
+	registry, err := core.NewTaikoNetworkRegistry()
+	if err != nil {
+		Fatalf("%v", err)
+	}
+	if ctx.IsSet(TaikoNetworksDirFlag.Name) {
+		if err := registry.LoadDir(ctx.String(TaikoNetworksDirFlag.Name)); err != nil {
+			Fatalf("%v", err)
+		}
+	}
+	network, err := registry.Resolve(ctx.String(TaikoNetworkFlag.Name), networkID)
+	if err != nil {
+		Fatalf("%v (available networks: %s)", err, strings.Join(registry.Names(), ", "))
+	}
+	return network
+}
+
+// RegisterTaikoAPIs initializes and registers the Taiko RPC APIs, if the node
+// runs a Taiko chain.
 func RegisterTaikoAPIs(stack *node.Node, cfg *ethconfig.Config, backend *eth.Ethereum) {
-	if os.Getenv("TAIKO_TEST") != "" {
+	if backend == nil || !backend.BlockChain().Config().Taiko {
 		return
 	}
+	taikoAPIBackend := eth.NewTaikoAPIBackend(backend)
 	// Add methods under "taiko_" RPC namespace to the available APIs list
 	stack.RegisterAPIs([]rpc.API{
 		{
 			Namespace: "taiko",
 			Version:   params.VersionWithMeta,
-			Service:   eth.NewTaikoAPIBackend(backend),
+			Service:   taikoAPIBackend,
+			Public:    true,
+		},
+		{
+			Namespace: "taiko",
+			Version:   params.VersionWithMeta,
+			Service:   eth.NewTaikoSubscriptionAPI(taikoAPIBackend),
 			Public:    true,
 		},
+		{
+			Namespace:     "taikoAuth",
+			Version:       params.VersionWithMeta,
+			Service:       eth.NewTaikoAuthAPIBackend(backend),
+			Authenticated: true,
+		},
+		{
+			Namespace: "admin",
+			Version:   params.VersionWithMeta,
+			Service:   eth.NewTaikoAdminAPI(backend),
+		},
 	})
 }
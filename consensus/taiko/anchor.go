@@ -0,0 +1,116 @@
+package taiko
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	ErrAnchorTxNotFound         = errors.New("anchor transaction not found")
+	ErrAnchorTxNotFirst         = errors.New("anchor transaction not the first transaction")
+	ErrInvalidAnchorTxSender    = errors.New("invalid anchor transaction sender")
+	ErrInvalidAnchorTxRecipient = errors.New("invalid anchor transaction recipient")
+	ErrInvalidAnchorTxCalldata  = errors.New("invalid anchor transaction calldata")
+	ErrInvalidAnchorTxGasLimit  = errors.New("invalid anchor transaction gas limit")
+)
+
+var (
+	// AnchorSelector is the selector of the default TaikoL2 anchor method,
+	// `TaikoL2.anchor(bytes32,bytes32,uint64,uint32)`.
+	AnchorSelector = crypto.Keccak256([]byte(params.DefaultAnchorMethod))[:4]
+
+	// AnchorV2Selector is the selector of `TaikoL2.anchorV2(uint64,bytes32,uint32,(uint8,uint8,uint32,uint64,uint32))`,
+	// which replaces TaikoL2.anchor since the Ontake fork.
+	AnchorV2Selector = crypto.Keccak256([]byte("anchorV2(uint64,bytes32,uint32,(uint8,uint8,uint32,uint64,uint32))"))[:4]
+
+	// anchorV2CalldataLength is the length of the ABI encoded TaikoL2.anchorV2
+	// calldata, three static arguments and a static tuple of five fields following
+	// the selector.
+	anchorV2CalldataLength = len(AnchorV2Selector) + 3*32 + 5*32
+)
+
+// VerifyBody checks whether the given block's transactions conform to the Taiko
+// protocol settings of the chain, i.e. the first transaction is a valid TaikoL2.anchor
+// transaction, and no other transaction is sent by the anchor transaction sender.
+func (t *Taiko) VerifyBody(chain consensus.ChainHeaderReader, block *types.Block) error {
+	return VerifyAnchorTransactions(chain.Config(), block.Header(), block.Transactions())
+}
+
+// VerifyAnchorTransactions checks the anchor transaction of the given L2 block's
+// transactions.
+func VerifyAnchorTransactions(config *params.ChainConfig, header *types.Header, txs types.Transactions) error {
+	if len(txs) == 0 {
+		return ErrAnchorTxNotFound
+	}
+
+	var (
+		taikoConfig = config.TaikoParams()
+		signer      = types.MakeSigner(config, header.Number)
+	)
+	if err := verifyAnchorTransaction(taikoConfig, config.IsOntake(header.Number), signer, txs[0]); err != nil {
+		return err
+	}
+
+	for i, tx := range txs[1:] {
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction %d: %w", i+1, err)
+		}
+		if sender == taikoConfig.AnchorSender {
+			return fmt.Errorf("%w: found at index %d", ErrAnchorTxNotFirst, i+1)
+		}
+	}
+
+	return nil
+}
+
+// anchorMethod returns the selector and the calldata length of the TaikoL2 method
+// called by the anchor transaction, the one of the network before Ontake, and
+// TaikoL2.anchorV2 since Ontake.
+func anchorMethod(config *params.TaikoConfig, isOntake bool) ([]byte, int, error) {
+	if isOntake {
+		return AnchorV2Selector, anchorV2CalldataLength, nil
+	}
+	signature := config.AnchorMethodSignature()
+	calldataLength, err := params.AnchorCalldataLength(signature)
+	if err != nil {
+		return nil, 0, err
+	}
+	return crypto.Keccak256([]byte(signature))[:4], calldataLength, nil
+}
+
+// verifyAnchorTransaction checks whether the given transaction is a valid
+// TaikoL2.anchor transaction, or TaikoL2.anchorV2 transaction since Ontake.
+func verifyAnchorTransaction(config *params.TaikoConfig, isOntake bool, signer types.Signer, tx *types.Transaction) error {
+	sender, err := types.Sender(signer, tx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAnchorTxSender, err)
+	}
+	if sender != config.AnchorSender {
+		return fmt.Errorf("%w: have %s, want %s", ErrInvalidAnchorTxSender, sender, config.AnchorSender)
+	}
+
+	if to := tx.To(); to == nil || *to != config.L2Contract {
+		return fmt.Errorf("%w: have %v, want %s", ErrInvalidAnchorTxRecipient, to, config.L2Contract)
+	}
+
+	selector, calldataLength, err := anchorMethod(config, isOntake)
+	if err != nil {
+		return err
+	}
+	if data := tx.Data(); len(data) != calldataLength || !bytes.Equal(data[:len(selector)], selector) {
+		return fmt.Errorf("%w: have %#x", ErrInvalidAnchorTxCalldata, data)
+	}
+
+	if tx.Gas() != config.AnchorGasLimit {
+		return fmt.Errorf("%w: have %d, want %d", ErrInvalidAnchorTxGasLimit, tx.Gas(), config.AnchorGasLimit)
+	}
+
+	return nil
+}
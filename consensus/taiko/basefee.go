@@ -0,0 +1,265 @@
+package taiko
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	ErrInvalidBaseFee   = errors.New("invalid base fee")
+	ErrMissingGasExcess = errors.New("missing parent gas excess")
+	errExpInputOverflow = errors.New("exp input overflow")
+)
+
+const (
+	// gasExcessCacheLimit is the number of recent blocks whose gas excess is kept,
+	// it covers the header batches verified at once.
+	gasExcessCacheLimit = 8192
+
+	// gasExcessCheckpointInterval is the number of blocks after which the gas excess
+	// is persisted, bounding the headers walked back after a restart.
+	gasExcessCheckpointInterval = 1024
+
+	// gasExcessLogInterval is the number of headers walked back between two logs,
+	// when deriving the gas excess of a block far from any known one.
+	gasExcessLogInterval = 100_000
+)
+
+var (
+	wad          = big.NewInt(1e18)                       // LibFixedPointMath.SCALING_FACTOR
+	maxExpInput  = mustParseBig("135305999368893231588")  // LibFixedPointMath.MAX_EXP_INPUT
+	minExpInput  = mustParseBig("-42139678854452767551")  // Largest input whose exponential rounds to zero
+	maxGasExcess = new(big.Int).SetUint64(math.MaxUint64) // The TaikoL2 contract stores the gas excess in an uint64
+
+	// The constants of the rational approximation of LibFixedPointMath.exp, in
+	// 96 bits fixed point.
+	expLn2    = mustParseBig("54916777467707473351141471128")
+	expHalf   = new(big.Int).Lsh(common.Big1, 95)
+	expFive18 = new(big.Int).Exp(big.NewInt(5), big.NewInt(18), nil)
+	expScale  = mustParseBig("3822833074963236453042738258902158003155416615667")
+	expP0     = mustParseBig("1346386616545796478920950773328")
+	expP1     = mustParseBig("57155421227552351082224309758442")
+	expP2     = mustParseBig("94201549194550492254356042504812")
+	expP3     = mustParseBig("28719021644029726153956944680412240")
+	expP4     = new(big.Int).Lsh(mustParseBig("4385272521454847904659076985693276"), 96)
+	expQ0     = mustParseBig("2855989394907223263936484059900")
+	expQ1     = mustParseBig("50020603652535783019961831881945")
+	expQ2     = mustParseBig("533845033583426703283633433725380")
+	expQ3     = mustParseBig("3604857256930695427073651918091429")
+	expQ4     = mustParseBig("14423608567350463180887372962807573")
+	expQ5     = mustParseBig("26449188498355588339934803723976023")
+)
+
+func mustParseBig(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big integer " + s)
+	}
+	return n
+}
+
+// mulShr96 returns (a * b) >> 96, rounded towards negative infinity.
+func mulShr96(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Rsh(r, 96)
+}
+
+// expWad returns e^(x / 1e18) * 1e18 for the given 18 decimals fixed point x,
+// rounded down, as LibFixedPointMath.exp of the TaikoL2 contract (i.e. Solmate's
+// wadExp): x is reduced to [-ln(2)/2, ln(2)/2] and e^x is approximated by a (6, 7)
+// rational function.
+func expWad(x *big.Int) (*big.Int, error) {
+	if x.Cmp(minExpInput) <= 0 {
+		return new(big.Int), nil
+	}
+	if x.Cmp(maxExpInput) > 0 {
+		return nil, errExpInputOverflow
+	}
+	// Convert x to a 96 bits fixed point, and reduce it to k * ln(2) + x.
+	x = new(big.Int).Lsh(x, 78)
+	x.Quo(x, expFive18)
+
+	k := new(big.Int).Lsh(x, 96)
+	k.Quo(k, expLn2)
+	k.Add(k, expHalf)
+	k.Rsh(k, 96)
+	x.Sub(x, new(big.Int).Mul(k, expLn2))
+
+	// Evaluate the rational approximation, p is made monic by the scaling below.
+	y := mulShr96(new(big.Int).Add(x, expP0), x)
+	y.Add(y, expP1)
+	p := new(big.Int).Add(y, x)
+	p.Sub(p, expP2)
+	p = mulShr96(p, y)
+	p.Add(p, expP3)
+	p.Mul(p, x)
+	p.Add(p, expP4)
+
+	q := new(big.Int).Sub(x, expQ0)
+	q = mulShr96(q, x)
+	q.Add(q, expQ1)
+	q = mulShr96(q, x)
+	q.Sub(q, expQ2)
+	q = mulShr96(q, x)
+	q.Add(q, expQ3)
+	q = mulShr96(q, x)
+	q.Sub(q, expQ4)
+	q = mulShr96(q, x)
+	q.Add(q, expQ5)
+
+	// Divide with the rounding of Solidity, and scale by 2^k and 1e18.
+	r := new(big.Int).Quo(p, q)
+	r.Mul(r, expScale)
+	return r.Rsh(r, uint(195-k.Int64())), nil
+}
+
+// baseFee returns the base fee at the given gas excess, as LibEIP1559.basefee
+// of the TaikoL2 contract: the derivative of e^(gasExcess / gasTarget), the cost
+// of the gas excess, which is never zero.
+func baseFee(gasTarget uint64, gasExcess uint64) *big.Int {
+	if gasTarget == 0 {
+		return big.NewInt(1)
+	}
+	target := new(big.Int).SetUint64(gasTarget)
+	input := new(big.Int).Mul(wad, new(big.Int).SetUint64(gasExcess))
+	input.Quo(input, target)
+	if input.Cmp(maxExpInput) > 0 {
+		input.Set(maxExpInput)
+	}
+	// The input is within bounds, the exponential can't overflow.
+	fee, _ := expWad(input)
+	fee.Quo(fee, wad)
+	fee.Quo(fee, target)
+	if fee.Sign() == 0 {
+		fee.SetUint64(1)
+	}
+	return fee
+}
+
+// CalcBaseFee calculates the L2 base fee of a block, and the gas excess stored
+// with it by the TaikoL2 contract, from the gas excess stored with its parent
+// block, the gas used by its parent block and the seconds elapsed since, like
+// TaikoL2.getBasefeeV2: the gas used by the parent block is added to the gas
+// excess, and the gas issued over the elapsed time is removed from it.
+func CalcBaseFee(config *params.TaikoBaseFeeConfig, parentGasExcess, parentGasUsed, blockTime uint64) (*big.Int, uint64) {
+	excess := new(big.Int).SetUint64(parentGasExcess)
+	excess.Add(excess, new(big.Int).SetUint64(parentGasUsed))
+	if issuance := config.GasIssuance(blockTime); excess.Cmp(issuance) > 0 {
+		excess.Sub(excess, issuance)
+	} else {
+		excess.SetUint64(1)
+	}
+	if excess.Cmp(maxGasExcess) > 0 {
+		excess.Set(maxGasExcess)
+	}
+	gasExcess := excess.Uint64()
+	if gasExcess < config.MinGasExcess {
+		gasExcess = config.MinGasExcess
+	}
+	return baseFee(config.GasTarget(), gasExcess), gasExcess
+}
+
+// gasExcess returns the gas excess stored by the TaikoL2 contract with the given
+// block, derived from the headers since the newest known gas excess, either a
+// recent one or a persisted checkpoint. The gas excess of the genesis block and of
+// the blocks before the activation is the configured initial one.
+func (t *Taiko) gasExcess(chain consensus.ChainHeaderReader, config *params.TaikoBaseFeeConfig, header *types.Header) (uint64, error) {
+	// Walk back to a block whose gas excess is known.
+	var (
+		headers []*types.Header
+		excess  = config.InitialGasExcess
+	)
+	for header.Number.Sign() > 0 && config.IsActive(header.Number) {
+		if cached, ok := t.gasExcesses.Get(header.Hash()); ok {
+			excess = cached
+			break
+		}
+		if t.db != nil && header.Number.Uint64()%gasExcessCheckpointInterval == 0 {
+			if stored, ok := rawdb.ReadTaikoGasExcess(t.db, header.Hash()); ok {
+				log.Trace("Loaded gas excess checkpoint from disk", "number", header.Number, "hash", header.Hash())
+				excess = stored
+				t.gasExcesses.Add(header.Hash(), excess)
+				break
+			}
+		}
+		headers = append(headers, header)
+		if header = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1); header == nil {
+			return 0, fmt.Errorf("%w: unknown ancestor %d", ErrMissingGasExcess, headers[len(headers)-1].Number.Uint64()-1)
+		}
+		if len(headers)%gasExcessLogInterval == 0 {
+			log.Info("Deriving taiko gas excess", "number", header.Number, "headers", len(headers))
+		}
+	}
+	// And derive the gas excess of the following blocks.
+	for i := len(headers) - 1; i >= 0; i-- {
+		_, excess = CalcBaseFee(config, excess, header.GasUsed, headers[i].Time-header.Time)
+		header = headers[i]
+		t.storeGasExcess(header, excess)
+	}
+	return excess, nil
+}
+
+// storeGasExcess remembers the gas excess stored with the given block, and also
+// persists it at the checkpoint blocks.
+func (t *Taiko) storeGasExcess(header *types.Header, excess uint64) {
+	t.gasExcesses.Add(header.Hash(), excess)
+	if t.db != nil && header.Number.Uint64()%gasExcessCheckpointInterval == 0 {
+		rawdb.WriteTaikoGasExcess(t.db, header.Hash(), excess)
+	}
+}
+
+// deriveGasExcesses derives the gas excess of a batch of consecutive headers, so
+// that their base fees can then be verified concurrently.
+func (t *Taiko) deriveGasExcesses(chain consensus.ChainHeaderReader, headers []*types.Header) {
+	config := chain.Config().TaikoParams().BaseFeeConfig
+	if config == nil || len(headers) == 0 {
+		return
+	}
+	parent := chain.GetHeader(headers[0].ParentHash, headers[0].Number.Uint64()-1)
+	if parent == nil {
+		return
+	}
+	excess, err := t.gasExcess(chain, config, parent)
+	if err != nil {
+		return
+	}
+	for _, header := range headers {
+		if header.ParentHash != parent.Hash() || !config.IsActive(header.Number) {
+			return
+		}
+		_, excess = CalcBaseFee(config, excess, parent.GasUsed, header.Time-parent.Time)
+		t.storeGasExcess(header, excess)
+		parent = header
+	}
+}
+
+// verifyBaseFee checks whether the base fee of the given header is derived from
+// its parent, if the chain config specifies the base fee parameters. The parent
+// gas excess is derived from the headers, assuming that the TaikoL2 contract is
+// always given the gas used by the parent block.
+func (t *Taiko) verifyBaseFee(chain consensus.ChainHeaderReader, parent, header *types.Header) error {
+	config := chain.Config().TaikoParams().BaseFeeConfig
+	if !config.IsActive(header.Number) {
+		return nil
+	}
+	parentGasExcess, err := t.gasExcess(chain, config, parent)
+	if err != nil {
+		return err
+	}
+	expected, gasExcess := CalcBaseFee(config, parentGasExcess, parent.GasUsed, header.Time-parent.Time)
+	if header.BaseFee.Cmp(expected) != 0 {
+		return fmt.Errorf("%w: have %v, want %v, parentGasExcess %d, parentGasUsed %d",
+			ErrInvalidBaseFee, header.BaseFee, expected, parentGasExcess, parent.GasUsed)
+	}
+	t.storeGasExcess(header, gasExcess)
+	return nil
+}
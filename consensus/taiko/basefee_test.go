@@ -0,0 +1,199 @@
+package taiko
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBaseFeeConfig is a set of base fee parameters like the Taiko networks ones.
+var testBaseFeeConfig = &params.TaikoBaseFeeConfig{
+	ActivationBlock:        common.Big0,
+	InitialGasExcess:       1_340_000_000,
+	AdjustmentQuotient:     8,
+	GasIssuancePerSecond:   5_000_000,
+	MinGasExcess:           1_340_000_000,
+	MaxGasIssuancePerBlock: 600_000_000,
+}
+
+func TestExpWad(t *testing.T) {
+	// The test vectors of Solmate's wadExp, which LibFixedPointMath.exp is.
+	tests := []struct {
+		x        string
+		expected string
+	}{
+		{"-42139678854452767551", "0"},
+		{"-3000000000000000000", "49787068367863942"},
+		{"-2000000000000000000", "135335283236612691"},
+		{"-1000000000000000000", "367879441171442321"},
+		{"-500000000000000000", "606530659712633423"},
+		{"-300000000000000000", "740818220681717866"},
+		{"0", "1000000000000000000"},
+		{"300000000000000000", "1349858807576003103"},
+		{"500000000000000000", "1648721270700128146"},
+		{"1000000000000000000", "2718281828459045235"},
+		{"2000000000000000000", "7389056098930650227"},
+		{"3000000000000000000", "20085536923187667741"},
+		{"135305999368893231588", "57896044618658097650144101621524338577433870140581303254786265309376407432913"},
+	}
+	for _, test := range tests {
+		r, err := expWad(mustParseBig(test.x))
+		assert.NoError(t, err)
+		assert.Equalf(t, mustParseBig(test.expected), r, "exp(%s)", test.x)
+	}
+
+	_, err := expWad(mustParseBig("135305999368893231589"))
+	assert.ErrorIs(t, err, errExpInputOverflow)
+}
+
+func TestCalcBaseFee(t *testing.T) {
+	tests := []struct {
+		parentGasExcess uint64
+		parentGasUsed   uint64
+		blockTime       uint64
+		baseFee         string
+		gasExcess       uint64
+	}{
+		{1_340_000_000, 0, 12, "8847185", 1_340_000_000},            // min gas excess
+		{1_340_000_000, 100_000_000, 12, "24049144", 1_380_000_000}, // one gas target in excess
+		{1_380_000_000, 50_000_000, 2, "65372352", 1_420_000_000},   // two gas targets in excess
+		{2_000_000_000, 100_000_000, 12, "352337270606734", 2_040_000_000},
+		{2_000_000_000, 0, 1000, "39650336", 1_400_000_000}, // max gas issuance
+		{math.MaxUint64, math.MaxUint32, 0, "1447401115466452441253602540538108464435846753514532", math.MaxUint64},
+	}
+	for i, test := range tests {
+		baseFee, gasExcess := CalcBaseFee(testBaseFeeConfig, test.parentGasExcess, test.parentGasUsed, test.blockTime)
+		assert.Equalf(t, mustParseBig(test.baseFee), baseFee, "test %d", i)
+		assert.Equalf(t, test.gasExcess, gasExcess, "test %d", i)
+	}
+
+	// Without min gas excess, the gas excess is at least one, and the base fee
+	// is never zero.
+	config := *testBaseFeeConfig
+	config.MinGasExcess = 0
+	baseFee, gasExcess := CalcBaseFee(&config, 0, 0, 12)
+	assert.Equal(t, big.NewInt(1), baseFee)
+	assert.Equal(t, uint64(1), gasExcess)
+}
+
+// testHeaderChain is a consensus.ChainHeaderReader of a set of headers.
+type testHeaderChain struct {
+	config  *params.ChainConfig
+	headers map[common.Hash]*types.Header
+}
+
+func newTestHeaderChain(config *params.ChainConfig, headers ...*types.Header) *testHeaderChain {
+	chain := &testHeaderChain{config: config, headers: make(map[common.Hash]*types.Header)}
+	for _, header := range headers {
+		chain.headers[header.Hash()] = header
+	}
+	return chain
+}
+
+func (c *testHeaderChain) Config() *params.ChainConfig  { return c.config }
+func (c *testHeaderChain) CurrentHeader() *types.Header { return nil }
+func (c *testHeaderChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return c.headers[hash]
+}
+func (c *testHeaderChain) GetHeaderByNumber(number uint64) *types.Header { return nil }
+func (c *testHeaderChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.headers[hash]
+}
+func (c *testHeaderChain) GetTd(hash common.Hash, number uint64) *big.Int { return nil }
+
+func TestVerifyBaseFee(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Taiko = true
+
+	var (
+		genesis = &types.Header{Number: big.NewInt(0), BaseFee: big.NewInt(params.InitialBaseFee)}
+		block1  = &types.Header{Number: big.NewInt(1), ParentHash: genesis.Hash(), Time: 12, GasUsed: 100_000_000, BaseFee: big.NewInt(8847185)}
+		block2  = &types.Header{Number: big.NewInt(2), ParentHash: block1.Hash(), Time: 24, GasUsed: 50_000_000, BaseFee: big.NewInt(24049144)}
+		block3  = &types.Header{Number: big.NewInt(3), ParentHash: block2.Hash(), Time: 26, BaseFee: big.NewInt(65372352)}
+		chain   = newTestHeaderChain(&config, genesis, block1, block2)
+	)
+
+	// Without base fee parameters, any base fee is accepted.
+	assert.NoError(t, New(nil).verifyBaseFee(chain, block2, block3))
+
+	config.TaikoConfig = &params.TaikoConfig{BaseFeeConfig: testBaseFeeConfig}
+	baseFeeConfig := *testBaseFeeConfig
+	baseFeeConfig.ActivationBlock = big.NewInt(4)
+	config.TaikoConfig.BaseFeeConfig = &baseFeeConfig
+
+	// Before the activation block, any base fee is accepted.
+	assert.NoError(t, New(nil).verifyBaseFee(chain, block2, block3))
+
+	// Since the activation, the gas excess is derived from the ancestors.
+	baseFeeConfig.ActivationBlock = common.Big0
+	assert.NoError(t, New(nil).verifyBaseFee(chain, block2, block3))
+	assert.NoError(t, New(nil).verifyBaseFee(chain, genesis, block1))
+
+	invalid := types.CopyHeader(block3)
+	invalid.BaseFee = big.NewInt(65372351)
+	assert.ErrorIs(t, New(nil).verifyBaseFee(chain, block2, invalid), ErrInvalidBaseFee)
+
+	// The gas excess of the parent of the activation block is the initial one.
+	baseFeeConfig.ActivationBlock = big.NewInt(3)
+	baseFeeConfig.InitialGasExcess = 1_400_000_000
+	block3.BaseFee = big.NewInt(107780788)
+	assert.NoError(t, New(nil).verifyBaseFee(chain, block2, block3))
+
+	// The gas excess of the headers verified concurrently is derived first.
+	baseFeeConfig.ActivationBlock, baseFeeConfig.InitialGasExcess = common.Big0, testBaseFeeConfig.InitialGasExcess
+	block3.BaseFee = big.NewInt(65372352)
+	engine := New(nil)
+	engine.deriveGasExcesses(newTestHeaderChain(&config, genesis), []*types.Header{block1, block2, block3})
+	for _, header := range []*types.Header{block1, block2, block3} {
+		_, ok := engine.gasExcesses.Get(header.Hash())
+		assert.True(t, ok)
+	}
+	assert.NoError(t, engine.verifyBaseFee(newTestHeaderChain(&config, genesis), block2, block3))
+
+	// The gas excess can't be derived without the ancestors.
+	assert.ErrorIs(t, New(nil).verifyBaseFee(newTestHeaderChain(&config, block2), block2, block3), ErrMissingGasExcess)
+}
+
+func TestGasExcessCheckpoint(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Taiko = true
+	config.TaikoConfig = &params.TaikoConfig{BaseFeeConfig: testBaseFeeConfig}
+
+	headers := []*types.Header{{Number: big.NewInt(0), BaseFee: big.NewInt(params.InitialBaseFee)}}
+	for i := 1; i <= 2*gasExcessCheckpointInterval+10; i++ {
+		parent := headers[i-1]
+		headers = append(headers, &types.Header{
+			Number:     big.NewInt(int64(i)),
+			ParentHash: parent.Hash(),
+			Time:       parent.Time + 2,
+			GasUsed:    uint64(i%7) * 10_000_000,
+		})
+	}
+	var (
+		db   = rawdb.NewMemoryDatabase()
+		head = headers[len(headers)-1]
+	)
+	excess, err := New(db).gasExcess(newTestHeaderChain(&config, headers...), testBaseFeeConfig, head)
+	assert.NoError(t, err)
+
+	// The checkpoints are persisted, so that the gas excess can be derived again
+	// after a restart without the headers before the newest checkpoint.
+	checkpoint := headers[2*gasExcessCheckpointInterval]
+	_, ok := rawdb.ReadTaikoGasExcess(db, checkpoint.Hash())
+	assert.True(t, ok)
+
+	chain := newTestHeaderChain(&config, headers[2*gasExcessCheckpointInterval:]...)
+	restored, err := New(db).gasExcess(chain, testBaseFeeConfig, head)
+	assert.NoError(t, err)
+	assert.Equal(t, excess, restored)
+
+	// Without the database, the whole chain is needed.
+	_, err = New(nil).gasExcess(chain, testBaseFeeConfig, head)
+	assert.ErrorIs(t, err, ErrMissingGasExcess)
+}
@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -25,12 +27,18 @@ var (
 )
 
 // Taiko is a consensus engine used by L2 rollup.
-type Taiko struct{}
-
-var defaultTaiko = new(Taiko)
+type Taiko struct {
+	db          ethdb.Database                  // Database to store the gas excess checkpoints, if any
+	gasExcesses *lru.Cache[common.Hash, uint64] // Gas excess stored by the TaikoL2 contract with the recent blocks
+}
 
-func New() *Taiko {
-	return defaultTaiko
+// New creates a Taiko consensus engine, the gas excess checkpoints are persisted
+// into the given database, if not nil, so that they survive restarts.
+func New(db ethdb.Database) *Taiko {
+	return &Taiko{
+		db:          db,
+		gasExcesses: lru.NewCache[common.Hash, uint64](gasExcessCacheLimit),
+	}
 }
 
 // check all method stubs for interface `Engine` without affect performance.
@@ -70,6 +78,10 @@ func (t *Taiko) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*type
 		workers = len(headers)
 	}
 
+	// The base fee of a header depends on the gas excess of all its ancestors,
+	// derive it sequentially before the concurrent verification.
+	t.deriveGasExcesses(chain, headers)
+
 	// Create a task channel and spawn the verifiers
 	var (
 		inputs  = make(chan int)
@@ -162,11 +174,24 @@ func (t *Taiko) verifyHeader(chain consensus.ChainHeaderReader, header, parent *
 		return ErrEmptyBasefee
 	}
 
+	// BaseFee should be derived from the parent block, if the chain config
+	// specifies the base fee parameters
+	if err := t.verifyBaseFee(chain, parent, header); err != nil {
+		return err
+	}
+
 	// WithdrawalsHash should not be empty
 	if header.WithdrawalsHash == nil {
 		return ErrEmptyWithdrawalsHash
 	}
 
+	// Since Ontake, the extra data is the basefee sharing percentage
+	if chain.Config().IsOntake(header.Number) {
+		if err := verifyOntakeExtraData(header.Extra); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
@@ -24,6 +25,7 @@ var (
 	testL2RollupAddress = common.HexToAddress("0x79fcdef22feed20eddacbb2587640e45491b757f")
 	testKey, _          = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
 	testAddr            = crypto.PubkeyToAddress(testKey.PublicKey)
+	goldenTouchKey, _   = crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
 
 	genesis    *core.Genesis
 	txs        []*types.Transaction
@@ -36,7 +38,7 @@ func init() {
 	config.ArrowGlacierBlock = nil
 	config.Ethash = nil
 	config.Taiko = true
-	testEngine = taiko.New()
+	testEngine = taiko.New(nil)
 
 	genesis = &core.Genesis{
 		Config:     config,
@@ -48,6 +50,7 @@ func init() {
 	}
 
 	txs = []*types.Transaction{
+		newAnchorTx(0, params.AnchorGasLimit, taiko.AnchorSelector),
 		types.MustSignNewTx(testKey, types.LatestSigner(genesis.Config), &types.LegacyTx{
 			Nonce:    0,
 			Value:    big.NewInt(12),
@@ -73,6 +76,19 @@ func init() {
 	}
 }
 
+// newAnchorTx creates a TaikoL2.anchor transaction with the given gas limit and
+// selector.
+func newAnchorTx(nonce uint64, gasLimit uint64, selector []byte) *types.Transaction {
+	l2Address := genesis.Config.TaikoParams().L2Contract
+	return types.MustSignNewTx(goldenTouchKey, types.LatestSigner(genesis.Config), &types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+		Gas:      gasLimit,
+		To:       &l2Address,
+		Data:     append(common.CopyBytes(selector), make([]byte, 4*32)...),
+	})
+}
+
 func newTestBackend(t *testing.T) (*eth.Ethereum, []*types.Block) {
 	// Generate test chain.
 	blocks := generateTestChain()
@@ -210,3 +226,133 @@ func TestVerifyHeader(t *testing.T) {
 	}, true)
 	assert.ErrorContains(t, err, "uncles not empty", "VerifyHeader should throw ErrUnclesNotEmpty if uncles is not the empty hash")
 }
+
+func TestVerifyBody(t *testing.T) {
+	ethService, blocks := newTestBackend(t)
+
+	var (
+		chain  = ethService.BlockChain()
+		header = &types.Header{Number: common.Big2}
+		userTx = txs[1]
+	)
+	for _, b := range blocks[1:] {
+		assert.NoError(t, testEngine.VerifyBody(chain, b))
+	}
+
+	verify := func(txs ...*types.Transaction) error {
+		return testEngine.VerifyBody(chain, types.NewBlockWithHeader(header).WithBody(txs, nil))
+	}
+
+	assert.ErrorIs(t, verify(), taiko.ErrAnchorTxNotFound)
+	assert.ErrorIs(t, verify(userTx), taiko.ErrInvalidAnchorTxSender)
+	assert.ErrorIs(t, verify(userTx, newAnchorTx(1, params.AnchorGasLimit, taiko.AnchorSelector)), taiko.ErrInvalidAnchorTxSender)
+	assert.ErrorIs(t, verify(newAnchorTx(1, params.AnchorGasLimit, taiko.AnchorSelector), newAnchorTx(2, params.AnchorGasLimit, taiko.AnchorSelector)), taiko.ErrAnchorTxNotFirst)
+	assert.ErrorIs(t, verify(newAnchorTx(1, params.AnchorGasLimit-1, taiko.AnchorSelector)), taiko.ErrInvalidAnchorTxGasLimit)
+	assert.ErrorIs(t, verify(newAnchorTx(1, params.AnchorGasLimit, []byte{1, 2, 3, 4})), taiko.ErrInvalidAnchorTxCalldata)
+
+	wrongRecipient := types.MustSignNewTx(goldenTouchKey, types.LatestSigner(genesis.Config), &types.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+		Gas:      params.AnchorGasLimit,
+		To:       &testL2RollupAddress,
+		Data:     append(common.CopyBytes(taiko.AnchorSelector), make([]byte, 4*32)...),
+	})
+	assert.ErrorIs(t, verify(wrongRecipient), taiko.ErrInvalidAnchorTxRecipient)
+
+	// A block without a valid anchor transaction is rejected on insertion.
+	db := rawdb.NewMemoryDatabase()
+	gblock := genesis.MustCommit(db)
+	invalid, _ := core.GenerateChain(genesis.Config, gblock, testEngine, db, 1, func(i int, g *core.BlockGen) {
+		g.SetDifficulty(common.Big0)
+		g.AddTx(userTx)
+	})
+	chainDb := rawdb.NewMemoryDatabase()
+	genesis.MustCommit(chainDb)
+	bc, err := core.NewBlockChain(chainDb, nil, genesis, nil, testEngine, vm.Config{}, nil, nil)
+	assert.NoError(t, err)
+	defer bc.Stop()
+
+	_, err = bc.InsertChain(invalid)
+	assert.ErrorIs(t, err, taiko.ErrInvalidAnchorTxSender)
+}
+
+func TestOntakeExtraData(t *testing.T) {
+	for _, pctg := range []uint8{0, 50, 100} {
+		assert.Equal(t, pctg, taiko.DecodeOntakeExtraData(taiko.EncodeOntakeExtraData(pctg)))
+	}
+	assert.Equal(t, uint8(0), taiko.DecodeOntakeExtraData(taiko.EncodeOntakeExtraData(101)))
+	assert.Equal(t, uint8(0), taiko.DecodeOntakeExtraData([]byte("test_taiko")))
+}
+
+func TestVerifyAnchorTransactionsOntake(t *testing.T) {
+	config := *genesis.Config
+	config.OntakeBlock = common.Big2
+
+	var (
+		l2Address = config.TaikoParams().L2Contract
+		anchorV2  = types.MustSignNewTx(goldenTouchKey, types.LatestSigner(&config), &types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      params.AnchorGasLimit,
+			To:       &l2Address,
+			Data:     append(common.CopyBytes(taiko.AnchorV2Selector), make([]byte, 8*32)...),
+		})
+		legacy = newAnchorTx(1, params.AnchorGasLimit, taiko.AnchorSelector)
+	)
+
+	// Before Ontake only TaikoL2.anchor is accepted, since Ontake only TaikoL2.anchorV2.
+	preOntake := &types.Header{Number: common.Big1}
+	assert.NoError(t, taiko.VerifyAnchorTransactions(&config, preOntake, types.Transactions{legacy}))
+	assert.ErrorIs(t, taiko.VerifyAnchorTransactions(&config, preOntake, types.Transactions{anchorV2}), taiko.ErrInvalidAnchorTxCalldata)
+
+	ontake := &types.Header{Number: common.Big2}
+	assert.NoError(t, taiko.VerifyAnchorTransactions(&config, ontake, types.Transactions{anchorV2}))
+	assert.ErrorIs(t, taiko.VerifyAnchorTransactions(&config, ontake, types.Transactions{legacy}), taiko.ErrInvalidAnchorTxCalldata)
+}
+
+func TestVerifyAnchorTransactionsNetworks(t *testing.T) {
+	// ERC-1967 implementation slot of the TaikoL2 proxies.
+	implementationSlot := common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	registry, err := core.NewTaikoNetworkRegistry()
+	assert.NoError(t, err)
+	for _, name := range registry.Names() {
+		network, err := registry.Network(name)
+		assert.NoError(t, err)
+
+		var (
+			config      = network.Config
+			taikoConfig = config.TaikoParams()
+			header      = &types.Header{Number: common.Big1}
+			method      = taikoConfig.AnchorMethodSignature()
+			selector    = crypto.Keccak256([]byte(method))[:4]
+		)
+		length, err := params.AnchorCalldataLength(method)
+		assert.NoError(t, err, name)
+
+		newAnchor := func(data []byte) *types.Transaction {
+			return types.MustSignNewTx(goldenTouchKey, types.MakeSigner(config, header.Number), &types.LegacyTx{
+				GasPrice: big.NewInt(params.InitialBaseFee),
+				Gas:      taikoConfig.AnchorGasLimit,
+				To:       &taikoConfig.L2Contract,
+				Data:     data,
+			})
+		}
+		anchor := newAnchor(append(common.CopyBytes(selector), make([]byte, length-len(selector))...))
+		assert.NoError(t, taiko.VerifyAnchorTransactions(config, header, types.Transactions{anchor}), name)
+
+		// The anchor method is implemented by the genesis TaikoL2 contract, or by
+		// the implementation behind the TaikoL2 proxy.
+		contract := network.Alloc[taikoConfig.L2Contract]
+		if implementation, ok := contract.Storage[implementationSlot]; ok {
+			contract = network.Alloc[common.BytesToAddress(implementation.Bytes())]
+		}
+		assert.True(t, bytes.Contains(contract.Code, append([]byte{byte(vm.PUSH4)}, selector...)), "%s: %s not implemented", name, method)
+
+		// The anchor transactions of the other TaikoL2 versions are rejected.
+		if method != params.DefaultAnchorMethod {
+			legacy := newAnchor(append(common.CopyBytes(taiko.AnchorSelector), make([]byte, 4*32)...))
+			assert.ErrorIs(t, taiko.VerifyAnchorTransactions(config, header, types.Transactions{legacy}), taiko.ErrInvalidAnchorTxCalldata, name)
+		}
+	}
+}
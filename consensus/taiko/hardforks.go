@@ -0,0 +1,42 @@
+package taiko
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidOntakeExtraData = errors.New("invalid ontake extra data")
+
+// EncodeOntakeExtraData encodes the given basefee sharing percentage into a block
+// extra data, since the Ontake fork the extra data of a L2 block is the percentage
+// as a big-endian uint256.
+func EncodeOntakeExtraData(basefeeSharingPctg uint8) []byte {
+	return common.LeftPadBytes([]byte{basefeeSharingPctg}, common.HashLength)
+}
+
+// DecodeOntakeExtraData decodes the basefee sharing percentage from the given
+// block extra data, zero is returned if the extra data is not a valid Ontake one.
+func DecodeOntakeExtraData(extra []byte) uint8 {
+	if verifyOntakeExtraData(extra) != nil {
+		return 0
+	}
+	return extra[common.HashLength-1]
+}
+
+// verifyOntakeExtraData checks whether the given block extra data is a valid
+// basefee sharing percentage.
+func verifyOntakeExtraData(extra []byte) error {
+	if len(extra) != common.HashLength {
+		return ErrInvalidOntakeExtraData
+	}
+	for _, b := range extra[:common.HashLength-1] {
+		if b != 0 {
+			return ErrInvalidOntakeExtraData
+		}
+	}
+	if extra[common.HashLength-1] > 100 {
+		return ErrInvalidOntakeExtraData
+	}
+	return nil
+}
@@ -204,6 +204,14 @@ type BlockChain struct {
 	currentFinalBlock atomic.Pointer[types.Header] // Latest (consensus) finalized block
 	currentSafeBlock  atomic.Pointer[types.Header] // Latest (consensus) safe block
 
+	// CHANGE(taiko): newest L2 block built ahead of its inclusion on L1.
+	currentPreconfBlock atomic.Pointer[types.Header]
+	preconfHeadFeed     event.Feed
+	preconfRollbackFeed event.Feed
+
+	// CHANGE(taiko): next block whose L1Origin is checked for L1 finality.
+	l1FinalityCursor atomic.Uint64
+
 	bodyCache     *lru.Cache[common.Hash, *types.Body]
 	bodyRLPCache  *lru.Cache[common.Hash, rlp.RawValue]
 	receiptsCache *lru.Cache[common.Hash, []*types.Receipt]
@@ -432,6 +440,14 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		}
 		rawdb.WriteChainConfig(db, genesisHash, chainConfig)
 	}
+	// CHANGE(taiko): index the L1Origins written before the reverse indexes existed.
+	if bc.chainConfig.Taiko && !rawdb.HasL1OriginIndexes(db) {
+		count, err := rawdb.BackfillL1OriginIndexes(db)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Indexed L1Origins", "items", count)
+	}
 	// Start tx indexer/unindexer if required.
 	if txLookupLimit != nil {
 		bc.txLookupLimit = *txLookupLimit
@@ -508,6 +524,10 @@ func (bc *BlockChain) loadLastState() error {
 			headSafeBlockGauge.Update(int64(block.NumberU64()))
 		}
 	}
+	// CHANGE(taiko): restore the newest preconfirmed block.
+	if bc.chainConfig.Taiko {
+		bc.loadPreconfHead()
+	}
 	// Issue a status log for the user
 	var (
 		currentSnapBlock  = bc.CurrentSnapBlock()
@@ -620,6 +640,9 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 	// Track the block number of the requested root hash
 	var rootNumber uint64 // (no root == always 0)
 
+	// CHANGE(taiko): track the original head to clean up the rewound L1Origins.
+	oldHead := bc.CurrentBlock().Number.Uint64()
+
 	// Retrieve the last pivot block to short circuit rollbacks beyond it and the
 	// current freezer limit to start nuking id underflown
 	pivot := rawdb.ReadLastPivotNumber(bc.db)
@@ -765,6 +788,14 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 	bc.txLookupCache.Purge()
 	bc.futureBlocks.Purge()
 
+	// CHANGE(taiko): remove the L1Origins and the preconfirmed head of the rewound
+	// blocks. The L1Origins are only written for L1 derived chains, they're removed
+	// whatever the chain config, so that rewinding never leaves any of them behind.
+	bc.repairL1Origins(bc.CurrentBlock().Number.Uint64(), oldHead)
+	if bc.chainConfig.Taiko {
+		bc.rollbackPreconfirmed()
+	}
+
 	// Clear safe block, finalized block if needed
 	if safe := bc.CurrentSafeBlock(); safe != nil && head < safe.Number.Uint64() {
 		log.Warn("SetHead invalidated safe block")
@@ -887,6 +918,11 @@ func (bc *BlockChain) ExportN(w io.Writer, first uint64, last uint64) error {
 //
 // Note, this function assumes that the `mu` mutex is held!
 func (bc *BlockChain) writeHeadBlock(block *types.Block) {
+	// CHANGE(taiko): track the fees of the blocks becoming canonical, whichever
+	// way they are inserted.
+	if bc.chainConfig.Taiko && metrics.Enabled && rawdb.ReadCanonicalHash(bc.db, block.NumberU64()) != block.Hash() {
+		bc.updateTaikoFeeMetrics(block, true)
+	}
 	// Add the block to the canonical chain number scheme and mark as the head
 	batch := bc.db.NewBatch()
 	rawdb.WriteHeadHeaderHash(batch, block.Hash())
@@ -1350,6 +1386,10 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteTd(blockBatch, block.Hash(), block.NumberU64(), externTd)
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	// CHANGE(taiko): account the fees of the block alongside its receipts.
+	if bc.chainConfig.Taiko {
+		rawdb.WriteTaikoFeeStats(blockBatch, CalcTaikoFeeStats(bc.chainConfig, block, receipts))
+	}
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
@@ -2155,6 +2195,12 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 		// rewind the canonical chain to a lower point.
 		log.Error("Impossible reorg, please file an issue", "oldnum", oldBlock.Number(), "oldhash", oldBlock.Hash(), "oldblocks", len(oldChain), "newnum", newBlock.Number(), "newhash", newBlock.Hash(), "newblocks", len(newChain))
 	}
+	// CHANGE(taiko): the fees of the reorged blocks are no longer accounted.
+	if bc.chainConfig.Taiko && metrics.Enabled {
+		for _, block := range oldChain {
+			bc.updateTaikoFeeMetrics(block, false)
+		}
+	}
 	// Insert the new chain(except the head block(reverse order)),
 	// taking care of the proper incremental order.
 	for i := len(newChain) - 1; i >= 1; i-- {
@@ -2265,13 +2311,20 @@ func (bc *BlockChain) SetCanonical(head *types.Block) (common.Hash, error) {
 	}
 	// Run the reorg if necessary and set the given block as new head.
 	start := time.Now()
-	if head.ParentHash() != bc.CurrentBlock().Hash() {
-		if err := bc.reorg(bc.CurrentBlock(), head); err != nil {
+	oldHead := bc.CurrentBlock()
+	if head.ParentHash() != oldHead.Hash() {
+		if err := bc.reorg(oldHead, head); err != nil {
 			return common.Hash{}, err
 		}
 	}
 	bc.writeHeadBlock(head)
 
+	// CHANGE(taiko): remove the L1Origins and the preconfirmed head of the reorged blocks.
+	if bc.chainConfig.Taiko {
+		bc.repairL1Origins(bc.canonicalAncestor(oldHead), oldHead.Number.Uint64())
+		bc.rollbackPreconfirmed()
+	}
+
 	// Emit events
 	logs := bc.collectLogs(head, false)
 	bc.chainFeed.Send(ChainEvent{Block: head, Hash: head.Hash(), Logs: logs})
@@ -66,6 +66,7 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		BaseFee:     baseFee,
 		GasLimit:    header.GasLimit,
 		Random:      random,
+		Extra:       header.Extra, // CHANGE(taiko): only decoded on Taiko networks since Ontake
 	}
 }
 
@@ -0,0 +1,42 @@
+package forkid
+
+import (
+	"hash/crc32"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the Taiko hard forks are part of the fork identifier.
+func TestTaikoForkID(t *testing.T) {
+	config := *params.TaikoChainConfig
+	config.OntakeBlock = big.NewInt(10)
+
+	var (
+		genesis   = common.HexToHash("0x01")
+		preOntake = crc32.ChecksumIEEE(genesis[:])
+		ontake    = checksumUpdate(preOntake, 10)
+	)
+
+	tests := []struct {
+		head, time uint64
+		want       ID
+	}{
+		{0, 0, ID{Hash: checksumToBytes(preOntake), Next: 10}},
+		{9, 1999, ID{Hash: checksumToBytes(preOntake), Next: 10}},
+		{10, 1000, ID{Hash: checksumToBytes(ontake), Next: 0}},
+		{20, 2000, ID{Hash: checksumToBytes(ontake), Next: 0}},
+	}
+	for i, tt := range tests {
+		if have := NewID(&config, genesis, tt.head, tt.time); have != tt.want {
+			t.Errorf("test %d: fork ID mismatch: have %x, want %x", i, have, tt.want)
+		}
+	}
+
+	// The fork identifiers of Taiko networks without the forks scheduled are unchanged.
+	if have, want := NewID(params.TaikoChainConfig, genesis, 20, 2000), (ID{Hash: checksumToBytes(preOntake)}); have != want {
+		t.Errorf("fork ID mismatch: have %x, want %x", have, want)
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var _ = (*preBuiltTxListMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (p PreBuiltTxList) MarshalJSON() ([]byte, error) {
+	type PreBuiltTxList struct {
+		TxList                types.Transactions `json:"txList" gencodec:"required"`
+		BytesLength           hexutil.Uint64     `json:"bytesLength" gencodec:"required"`
+		CompressedBytesLength hexutil.Uint64     `json:"compressedBytesLength" gencodec:"required"`
+		GasUsed               hexutil.Uint64     `json:"gasUsed"`
+		Tips                  *hexutil.Big       `json:"tips"`
+		Lanes                 []*TxLaneUsage     `json:"lanes,omitempty"`
+	}
+	var enc PreBuiltTxList
+	enc.TxList = p.TxList
+	enc.BytesLength = hexutil.Uint64(p.BytesLength)
+	enc.CompressedBytesLength = hexutil.Uint64(p.CompressedBytesLength)
+	enc.GasUsed = hexutil.Uint64(p.GasUsed)
+	enc.Tips = (*hexutil.Big)(p.Tips)
+	enc.Lanes = p.Lanes
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (p *PreBuiltTxList) UnmarshalJSON(input []byte) error {
+	type PreBuiltTxList struct {
+		TxList                *types.Transactions `json:"txList" gencodec:"required"`
+		BytesLength           *hexutil.Uint64     `json:"bytesLength" gencodec:"required"`
+		CompressedBytesLength *hexutil.Uint64     `json:"compressedBytesLength" gencodec:"required"`
+		GasUsed               *hexutil.Uint64     `json:"gasUsed"`
+		Tips                  *hexutil.Big        `json:"tips"`
+		Lanes                 []*TxLaneUsage      `json:"lanes,omitempty"`
+	}
+	var dec PreBuiltTxList
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.TxList == nil {
+		return errors.New("missing required field 'txList' for PreBuiltTxList")
+	}
+	p.TxList = *dec.TxList
+	if dec.BytesLength == nil {
+		return errors.New("missing required field 'bytesLength' for PreBuiltTxList")
+	}
+	p.BytesLength = uint64(*dec.BytesLength)
+	if dec.CompressedBytesLength == nil {
+		return errors.New("missing required field 'compressedBytesLength' for PreBuiltTxList")
+	}
+	p.CompressedBytesLength = uint64(*dec.CompressedBytesLength)
+	if dec.GasUsed != nil {
+		p.GasUsed = uint64(*dec.GasUsed)
+	}
+	if dec.Tips != nil {
+		p.Tips = (*big.Int)(dec.Tips)
+	}
+	if dec.Lanes != nil {
+		p.Lanes = dec.Lanes
+	}
+	return nil
+}
@@ -291,6 +291,10 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *trie.Database, gen
 	if genesis != nil && genesis.Config == nil {
 		return params.AllEthashProtocolChanges, common.Hash{}, errGenesisNoConfig
 	}
+	// CHANGE(taiko): the TaikoL2 contract of a Taiko chain is the predeployed one.
+	if genesis != nil {
+		genesis.deriveTaikoL2Contract()
+	}
 	applyOverrides := func(config *params.ChainConfig) {
 		if config != nil {
 			if overrides != nil && overrides.OverrideShanghai != nil {
@@ -480,6 +484,8 @@ func (g *Genesis) Commit(db ethdb.Database, triedb *trie.Database) (*types.Block
 	if block.Number().Sign() != 0 {
 		return nil, errors.New("can't commit genesis block with number > 0")
 	}
+	// CHANGE(taiko): the TaikoL2 contract of a Taiko chain is the predeployed one.
+	g.deriveTaikoL2Contract()
 	config := g.Config
 	if config == nil {
 		config = params.AllEthashProtocolChanges
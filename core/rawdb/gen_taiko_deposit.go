@@ -0,0 +1,67 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package rawdb
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*taikoDepositMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (t TaikoDeposit) MarshalJSON() ([]byte, error) {
+	type TaikoDeposit struct {
+		Index       hexutil.Uint64 `json:"index" gencodec:"required"`
+		Recipient   common.Address `json:"recipient" gencodec:"required"`
+		Amount      hexutil.Uint64 `json:"amount" gencodec:"required"`
+		BlockHash   common.Hash    `json:"blockHash" gencodec:"required"`
+		BlockNumber hexutil.Uint64 `json:"blockNumber" gencodec:"required"`
+	}
+	var enc TaikoDeposit
+	enc.Index = hexutil.Uint64(t.Index)
+	enc.Recipient = t.Recipient
+	enc.Amount = hexutil.Uint64(t.Amount)
+	enc.BlockHash = t.BlockHash
+	enc.BlockNumber = hexutil.Uint64(t.BlockNumber)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (t *TaikoDeposit) UnmarshalJSON(input []byte) error {
+	type TaikoDeposit struct {
+		Index       *hexutil.Uint64 `json:"index" gencodec:"required"`
+		Recipient   *common.Address `json:"recipient" gencodec:"required"`
+		Amount      *hexutil.Uint64 `json:"amount" gencodec:"required"`
+		BlockHash   *common.Hash    `json:"blockHash" gencodec:"required"`
+		BlockNumber *hexutil.Uint64 `json:"blockNumber" gencodec:"required"`
+	}
+	var dec TaikoDeposit
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Index == nil {
+		return errors.New("missing required field 'index' for TaikoDeposit")
+	}
+	t.Index = uint64(*dec.Index)
+	if dec.Recipient == nil {
+		return errors.New("missing required field 'recipient' for TaikoDeposit")
+	}
+	t.Recipient = *dec.Recipient
+	if dec.Amount == nil {
+		return errors.New("missing required field 'amount' for TaikoDeposit")
+	}
+	t.Amount = uint64(*dec.Amount)
+	if dec.BlockHash == nil {
+		return errors.New("missing required field 'blockHash' for TaikoDeposit")
+	}
+	t.BlockHash = *dec.BlockHash
+	if dec.BlockNumber == nil {
+		return errors.New("missing required field 'blockNumber' for TaikoDeposit")
+	}
+	t.BlockNumber = uint64(*dec.BlockNumber)
+	return nil
+}
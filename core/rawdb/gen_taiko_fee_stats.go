@@ -0,0 +1,75 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package rawdb
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*taikoFeeStatsMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (t TaikoFeeStats) MarshalJSON() ([]byte, error) {
+	type TaikoFeeStats struct {
+		BlockHash       common.Hash    `json:"blockHash" gencodec:"required"`
+		BlockNumber     hexutil.Uint64 `json:"blockNumber" gencodec:"required"`
+		ProposerTips    *hexutil.Big   `json:"proposerTips" gencodec:"required"`
+		SharedBaseFee   *hexutil.Big   `json:"sharedBaseFee" gencodec:"required"`
+		TreasuryBaseFee *hexutil.Big   `json:"treasuryBaseFee" gencodec:"required"`
+		AnchorGasUsed   hexutil.Uint64 `json:"anchorGasUsed" gencodec:"required"`
+	}
+	var enc TaikoFeeStats
+	enc.BlockHash = t.BlockHash
+	enc.BlockNumber = hexutil.Uint64(t.BlockNumber)
+	enc.ProposerTips = (*hexutil.Big)(t.ProposerTips)
+	enc.SharedBaseFee = (*hexutil.Big)(t.SharedBaseFee)
+	enc.TreasuryBaseFee = (*hexutil.Big)(t.TreasuryBaseFee)
+	enc.AnchorGasUsed = hexutil.Uint64(t.AnchorGasUsed)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (t *TaikoFeeStats) UnmarshalJSON(input []byte) error {
+	type TaikoFeeStats struct {
+		BlockHash       *common.Hash    `json:"blockHash" gencodec:"required"`
+		BlockNumber     *hexutil.Uint64 `json:"blockNumber" gencodec:"required"`
+		ProposerTips    *hexutil.Big    `json:"proposerTips" gencodec:"required"`
+		SharedBaseFee   *hexutil.Big    `json:"sharedBaseFee" gencodec:"required"`
+		TreasuryBaseFee *hexutil.Big    `json:"treasuryBaseFee" gencodec:"required"`
+		AnchorGasUsed   *hexutil.Uint64 `json:"anchorGasUsed" gencodec:"required"`
+	}
+	var dec TaikoFeeStats
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.BlockHash == nil {
+		return errors.New("missing required field 'blockHash' for TaikoFeeStats")
+	}
+	t.BlockHash = *dec.BlockHash
+	if dec.BlockNumber == nil {
+		return errors.New("missing required field 'blockNumber' for TaikoFeeStats")
+	}
+	t.BlockNumber = uint64(*dec.BlockNumber)
+	if dec.ProposerTips == nil {
+		return errors.New("missing required field 'proposerTips' for TaikoFeeStats")
+	}
+	t.ProposerTips = (*big.Int)(dec.ProposerTips)
+	if dec.SharedBaseFee == nil {
+		return errors.New("missing required field 'sharedBaseFee' for TaikoFeeStats")
+	}
+	t.SharedBaseFee = (*big.Int)(dec.SharedBaseFee)
+	if dec.TreasuryBaseFee == nil {
+		return errors.New("missing required field 'treasuryBaseFee' for TaikoFeeStats")
+	}
+	t.TreasuryBaseFee = (*big.Int)(dec.TreasuryBaseFee)
+	if dec.AnchorGasUsed == nil {
+		return errors.New("missing required field 'anchorGasUsed' for TaikoFeeStats")
+	}
+	t.AnchorGasUsed = uint64(*dec.AnchorGasUsed)
+	return nil
+}
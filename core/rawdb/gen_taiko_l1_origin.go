@@ -16,26 +16,29 @@ var _ = (*l1OriginMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (l L1Origin) MarshalJSON() ([]byte, error) {
 	type L1Origin struct {
-		BlockID       *math.HexOrDecimal256 `json:"blockID" gencodec:"required"`
-		L2BlockHash   common.Hash           `json:"l2BlockHash"`
-		L1BlockHeight *math.HexOrDecimal256 `json:"l1BlockHeight" gencodec:"required"`
-		L1BlockHash   common.Hash           `json:"l1BlockHash" gencodec:"required"`
+		BlockID        *math.HexOrDecimal256 `json:"blockID" gencodec:"required"`
+		L2BlockHash    common.Hash           `json:"l2BlockHash"`
+		L1BlockHeight  *math.HexOrDecimal256 `json:"l1BlockHeight" gencodec:"required"`
+		L1BlockHash    common.Hash           `json:"l1BlockHash" gencodec:"required"`
+		IsPreconfirmed bool                  `json:"isPreconfirmed" rlp:"optional"`
 	}
 	var enc L1Origin
 	enc.BlockID = (*math.HexOrDecimal256)(l.BlockID)
 	enc.L2BlockHash = l.L2BlockHash
 	enc.L1BlockHeight = (*math.HexOrDecimal256)(l.L1BlockHeight)
 	enc.L1BlockHash = l.L1BlockHash
+	enc.IsPreconfirmed = l.IsPreconfirmed
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (l *L1Origin) UnmarshalJSON(input []byte) error {
 	type L1Origin struct {
-		BlockID       *math.HexOrDecimal256 `json:"blockID" gencodec:"required"`
-		L2BlockHash   *common.Hash          `json:"l2BlockHash"`
-		L1BlockHeight *math.HexOrDecimal256 `json:"l1BlockHeight" gencodec:"required"`
-		L1BlockHash   *common.Hash          `json:"l1BlockHash" gencodec:"required"`
+		BlockID        *math.HexOrDecimal256 `json:"blockID" gencodec:"required"`
+		L2BlockHash    *common.Hash          `json:"l2BlockHash"`
+		L1BlockHeight  *math.HexOrDecimal256 `json:"l1BlockHeight" gencodec:"required"`
+		L1BlockHash    *common.Hash          `json:"l1BlockHash" gencodec:"required"`
+		IsPreconfirmed *bool                 `json:"isPreconfirmed" rlp:"optional"`
 	}
 	var dec L1Origin
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -56,5 +59,8 @@ func (l *L1Origin) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'l1BlockHash' for L1Origin")
 	}
 	l.L1BlockHash = *dec.L1BlockHash
+	if dec.IsPreconfirmed != nil {
+		l.IsPreconfirmed = *dec.IsPreconfirmed
+	}
 	return nil
 }
@@ -0,0 +1,53 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package rawdb
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*skippedTransactionMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (s SkippedTransaction) MarshalJSON() ([]byte, error) {
+	type SkippedTransaction struct {
+		Index  hexutil.Uint64 `json:"index" gencodec:"required"`
+		Hash   common.Hash    `json:"hash" gencodec:"required"`
+		Reason string         `json:"reason" gencodec:"required"`
+	}
+	var enc SkippedTransaction
+	enc.Index = hexutil.Uint64(s.Index)
+	enc.Hash = s.Hash
+	enc.Reason = s.Reason
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (s *SkippedTransaction) UnmarshalJSON(input []byte) error {
+	type SkippedTransaction struct {
+		Index  *hexutil.Uint64 `json:"index" gencodec:"required"`
+		Hash   *common.Hash    `json:"hash" gencodec:"required"`
+		Reason *string         `json:"reason" gencodec:"required"`
+	}
+	var dec SkippedTransaction
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Index == nil {
+		return errors.New("missing required field 'index' for SkippedTransaction")
+	}
+	s.Index = uint64(*dec.Index)
+	if dec.Hash == nil {
+		return errors.New("missing required field 'hash' for SkippedTransaction")
+	}
+	s.Hash = *dec.Hash
+	if dec.Reason == nil {
+		return errors.New("missing required field 'reason' for SkippedTransaction")
+	}
+	s.Reason = *dec.Reason
+	return nil
+}
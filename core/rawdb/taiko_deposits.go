@@ -0,0 +1,109 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// Database key prefixes for the L1 -> L2 ETH deposits index.
+	depositPrefix          = []byte("TKO:DEP") // depositPrefix + depositIndex (uint64 big endian) -> deposit
+	depositByAddressPrefix = []byte("TKO:DPA") // depositByAddressPrefix + recipient + depositIndex (uint64 big endian) -> nil
+
+	// TaikoDepositIndexPrefix is the data table of the deposits chain indexer to
+	// track its progress.
+	TaikoDepositIndexPrefix = []byte("iTD")
+)
+
+// depositKey = depositPrefix + depositIndex (uint64 big endian)
+func depositKey(index uint64) []byte {
+	return append(depositPrefix, encodeBlockNumber(index)...)
+}
+
+// depositByAddressKey = depositByAddressPrefix + recipient + depositIndex (uint64 big endian)
+func depositByAddressKey(recipient common.Address, index uint64) []byte {
+	return append(append(depositByAddressPrefix, recipient.Bytes()...), encodeBlockNumber(index)...)
+}
+
+//go:generate go run github.com/fjl/gencodec -type TaikoDeposit -field-override taikoDepositMarshaling -out gen_taiko_deposit.go
+
+// TaikoDeposit represents a L1 -> L2 ETH deposit, credited to its recipient as
+// a withdrawal of a L2 block.
+type TaikoDeposit struct {
+	Index       uint64         `json:"index" gencodec:"required"`
+	Recipient   common.Address `json:"recipient" gencodec:"required"`
+	Amount      uint64         `json:"amount" gencodec:"required"`
+	BlockHash   common.Hash    `json:"blockHash" gencodec:"required"`
+	BlockNumber uint64         `json:"blockNumber" gencodec:"required"`
+}
+
+type taikoDepositMarshaling struct {
+	Index       hexutil.Uint64
+	Amount      hexutil.Uint64
+	BlockNumber hexutil.Uint64
+}
+
+// WriteTaikoDeposit stores a deposit into the database, indexed by its deposit
+// index and by its recipient.
+func WriteTaikoDeposit(db ethdb.KeyValueWriter, deposit *TaikoDeposit) {
+	data, err := rlp.EncodeToBytes(deposit)
+	if err != nil {
+		log.Crit("Failed to encode deposit", "err", err)
+	}
+	if err := db.Put(depositKey(deposit.Index), data); err != nil {
+		log.Crit("Failed to store deposit", "err", err)
+	}
+	if err := db.Put(depositByAddressKey(deposit.Recipient, deposit.Index), nil); err != nil {
+		log.Crit("Failed to store deposit recipient index", "err", err)
+	}
+}
+
+// ReadTaikoDeposit retrieves the deposit with the given deposit index from the
+// database.
+func ReadTaikoDeposit(db ethdb.KeyValueReader, index uint64) (*TaikoDeposit, error) {
+	data, _ := db.Get(depositKey(index))
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	deposit := new(TaikoDeposit)
+	if err := rlp.DecodeBytes(data, deposit); err != nil {
+		return nil, fmt.Errorf("invalid deposit RLP bytes: %w", err)
+	}
+
+	return deposit, nil
+}
+
+// ReadTaikoDepositsByAddress retrieves at most limit deposits credited to the
+// given recipient, in ascending deposit index order, starting from the given
+// deposit index. Entries left behind by reorged blocks are skipped.
+func ReadTaikoDepositsByAddress(db ethdb.Database, recipient common.Address, start uint64, limit int) ([]*TaikoDeposit, error) {
+	prefix := append(common.CopyBytes(depositByAddressPrefix), recipient.Bytes()...)
+
+	it := db.NewIterator(prefix, encodeBlockNumber(start))
+	defer it.Release()
+
+	var deposits []*TaikoDeposit
+	for it.Next() && len(deposits) < limit {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		deposit, err := ReadTaikoDeposit(db, binary.BigEndian.Uint64(key[len(prefix):]))
+		if err != nil {
+			return nil, err
+		}
+		if deposit == nil || deposit.Recipient != recipient || ReadCanonicalHash(db, deposit.BlockNumber) != deposit.BlockHash {
+			continue
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	return deposits, it.Error()
+}
@@ -0,0 +1,75 @@
+package rawdb
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Database key prefix for the fee accounting of the L2 blocks.
+var feeStatsPrefix = []byte("TKO:FEE")
+
+// feeStatsKey = feeStatsPrefix + l2BlockHash
+func feeStatsKey(blockHash common.Hash) []byte {
+	return append(feeStatsPrefix, blockHash.Bytes()...)
+}
+
+//go:generate go run github.com/fjl/gencodec -type TaikoFeeStats -field-override taikoFeeStatsMarshaling -out gen_taiko_fee_stats.go
+
+// TaikoFeeStats represents the fees paid by the transactions of a L2 block, split
+// by their recipient.
+type TaikoFeeStats struct {
+	BlockHash       common.Hash `json:"blockHash" gencodec:"required"`
+	BlockNumber     uint64      `json:"blockNumber" gencodec:"required"`
+	ProposerTips    *big.Int    `json:"proposerTips" gencodec:"required"`    // Priority fees credited to the block proposer
+	SharedBaseFee   *big.Int    `json:"sharedBaseFee" gencodec:"required"`   // Part of the base fees shared with the block proposer
+	TreasuryBaseFee *big.Int    `json:"treasuryBaseFee" gencodec:"required"` // Base fees credited to the treasury
+	AnchorGasUsed   uint64      `json:"anchorGasUsed" gencodec:"required"`   // Gas used by the anchor transaction
+}
+
+type taikoFeeStatsMarshaling struct {
+	BlockNumber     hexutil.Uint64
+	ProposerTips    *hexutil.Big
+	SharedBaseFee   *hexutil.Big
+	TreasuryBaseFee *hexutil.Big
+	AnchorGasUsed   hexutil.Uint64
+}
+
+// ProposerFees returns the fees credited to the block proposer.
+func (s *TaikoFeeStats) ProposerFees() *big.Int {
+	return new(big.Int).Add(s.ProposerTips, s.SharedBaseFee)
+}
+
+// WriteTaikoFeeStats stores the fee accounting of the given L2 block into the
+// database.
+func WriteTaikoFeeStats(db ethdb.KeyValueWriter, stats *TaikoFeeStats) {
+	data, err := rlp.EncodeToBytes(stats)
+	if err != nil {
+		log.Crit("Failed to encode fee stats", "err", err)
+	}
+
+	if err := db.Put(feeStatsKey(stats.BlockHash), data); err != nil {
+		log.Crit("Failed to store fee stats", "err", err)
+	}
+}
+
+// ReadTaikoFeeStats retrieves the fee accounting of the given L2 block from the
+// database.
+func ReadTaikoFeeStats(db ethdb.KeyValueReader, blockHash common.Hash) (*TaikoFeeStats, error) {
+	data, _ := db.Get(feeStatsKey(blockHash))
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	stats := new(TaikoFeeStats)
+	if err := rlp.DecodeBytes(data, stats); err != nil {
+		return nil, fmt.Errorf("invalid fee stats RLP bytes: %w", err)
+	}
+
+	return stats, nil
+}
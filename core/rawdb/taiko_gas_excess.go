@@ -0,0 +1,35 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Database key prefix for the gas excess stored by the TaikoL2 contract with the
+// L2 blocks, only checkpoints are persisted.
+var gasExcessPrefix = []byte("TKO:GEX")
+
+// gasExcessKey = gasExcessPrefix + l2BlockHash
+func gasExcessKey(blockHash common.Hash) []byte {
+	return append(gasExcessPrefix, blockHash.Bytes()...)
+}
+
+// ReadTaikoGasExcess retrieves the gas excess stored with the given L2 block, and
+// whether it was found.
+func ReadTaikoGasExcess(db ethdb.KeyValueReader, blockHash common.Hash) (uint64, bool) {
+	data, _ := db.Get(gasExcessKey(blockHash))
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// WriteTaikoGasExcess stores the gas excess stored with the given L2 block.
+func WriteTaikoGasExcess(db ethdb.KeyValueWriter, blockHash common.Hash, gasExcess uint64) {
+	if err := db.Put(gasExcessKey(blockHash), binary.BigEndian.AppendUint64(nil, gasExcess)); err != nil {
+		log.Crit("Failed to store gas excess", "err", err)
+	}
+}
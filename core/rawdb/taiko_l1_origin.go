@@ -2,6 +2,8 @@ package rawdb
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -16,6 +18,19 @@ var (
 	// Database key prefix for L2 block's L1Origin.
 	l1OriginPrefix  = []byte("TKO:L1O")
 	headL1OriginKey = []byte("TKO:LastL1O")
+
+	// Database key prefixes for the L1 block -> L2 blocks reverse indexes.
+	l1OriginByL1HashPrefix   = []byte("TKO:L1H") // l1OriginByL1HashPrefix + l1BlockHash + blockID (uint64 big endian) -> nil
+	l1OriginByL1HeightPrefix = []byte("TKO:L1N") // l1OriginByL1HeightPrefix + l1BlockHeight (uint64 big endian) + blockID (uint64 big endian) -> nil
+
+	// Database key marking that the reverse indexes cover the L1Origins written
+	// before they were introduced.
+	l1OriginIndexesKey = []byte("TKO:IndexedL1O")
+)
+
+var (
+	errL1OriginAboveHead    = errors.New("L2 block above the chain head")
+	errL1OriginNotCanonical = errors.New("L2 block not canonical")
 )
 
 // l1OriginKey calculates the L1Origin key.
@@ -25,6 +40,16 @@ func l1OriginKey(blockID *big.Int) []byte {
 	return append(l1OriginPrefix, data...)
 }
 
+// l1OriginByL1HashKey = l1OriginByL1HashPrefix + l1BlockHash + blockID (uint64 big endian)
+func l1OriginByL1HashKey(l1BlockHash common.Hash, blockID *big.Int) []byte {
+	return append(append(l1OriginByL1HashPrefix, l1BlockHash.Bytes()...), encodeBlockNumber(blockID.Uint64())...)
+}
+
+// l1OriginByL1HeightKey = l1OriginByL1HeightPrefix + l1BlockHeight (uint64 big endian) + blockID (uint64 big endian)
+func l1OriginByL1HeightKey(l1BlockHeight *big.Int, blockID *big.Int) []byte {
+	return append(append(l1OriginByL1HeightPrefix, encodeBlockNumber(l1BlockHeight.Uint64())...), encodeBlockNumber(blockID.Uint64())...)
+}
+
 //go:generate go run github.com/fjl/gencodec -type L1Origin -field-override l1OriginMarshaling -out gen_taiko_l1_origin.go
 
 // L1Origin represents a L1Origin of a L2 block.
@@ -33,6 +58,10 @@ type L1Origin struct {
 	L2BlockHash   common.Hash `json:"l2BlockHash"`
 	L1BlockHeight *big.Int    `json:"l1BlockHeight" gencodec:"required"`
 	L1BlockHash   common.Hash `json:"l1BlockHash" gencodec:"required"`
+
+	// IsPreconfirmed marks a L2 block built locally ahead of its inclusion on L1,
+	// the flag is cleared once the block is derived from L1.
+	IsPreconfirmed bool `json:"isPreconfirmed" rlp:"optional"`
 }
 
 type l1OriginMarshaling struct {
@@ -40,16 +69,62 @@ type l1OriginMarshaling struct {
 	L1BlockHeight *math.HexOrDecimal256
 }
 
-// WriteL1Origin stores a L1Origin into the database.
-func WriteL1Origin(db ethdb.KeyValueWriter, blockID *big.Int, l1Origin *L1Origin) {
+// WriteL1Origin stores a L1Origin into the database, and keeps the L1 block hash
+// and L1 block height reverse indexes in sync with it.
+func WriteL1Origin(db ethdb.KeyValueStore, blockID *big.Int, l1Origin *L1Origin) {
+	WriteL1OriginBatch(db, db, blockID, l1Origin)
+}
+
+// WriteL1OriginBatch stores the given L1Origin into the given batch, the reverse
+// index entries of the L1Origin it overwrites are looked up in the database.
+func WriteL1OriginBatch(db ethdb.KeyValueReader, batch ethdb.KeyValueWriter, blockID *big.Int, l1Origin *L1Origin) {
 	data, err := rlp.EncodeToBytes(l1Origin)
 	if err != nil {
 		log.Crit("Failed to encode L1Origin", "err", err)
 	}
 
-	if err := db.Put(l1OriginKey(blockID), data); err != nil {
+	// Drop the reverse index entries of the overwritten L1Origin, if any.
+	if prev, err := ReadL1Origin(db, blockID); err == nil && prev != nil {
+		deleteL1OriginIndexes(batch, blockID, prev)
+	}
+
+	if err := batch.Put(l1OriginKey(blockID), data); err != nil {
 		log.Crit("Failed to store L1Origin", "err", err)
 	}
+	writeL1OriginIndexes(batch, blockID, l1Origin)
+}
+
+// DeleteL1Origin removes the given L1Origin and its reverse index entries from
+// the database.
+func DeleteL1Origin(db ethdb.KeyValueWriter, l1Origin *L1Origin) {
+	if err := db.Delete(l1OriginKey(l1Origin.BlockID)); err != nil {
+		log.Crit("Failed to delete L1Origin", "err", err)
+	}
+	deleteL1OriginIndexes(db, l1Origin.BlockID, l1Origin)
+}
+
+// writeL1OriginIndexes stores the reverse index entries of the given L1Origin.
+func writeL1OriginIndexes(db ethdb.KeyValueWriter, blockID *big.Int, l1Origin *L1Origin) {
+	if err := db.Put(l1OriginByL1HashKey(l1Origin.L1BlockHash, blockID), nil); err != nil {
+		log.Crit("Failed to store L1Origin L1 block hash index", "err", err)
+	}
+	if l1Origin.L1BlockHeight != nil {
+		if err := db.Put(l1OriginByL1HeightKey(l1Origin.L1BlockHeight, blockID), nil); err != nil {
+			log.Crit("Failed to store L1Origin L1 block height index", "err", err)
+		}
+	}
+}
+
+// deleteL1OriginIndexes removes the reverse index entries of the given L1Origin.
+func deleteL1OriginIndexes(db ethdb.KeyValueWriter, blockID *big.Int, l1Origin *L1Origin) {
+	if err := db.Delete(l1OriginByL1HashKey(l1Origin.L1BlockHash, blockID)); err != nil {
+		log.Crit("Failed to delete L1Origin L1 block hash index", "err", err)
+	}
+	if l1Origin.L1BlockHeight != nil {
+		if err := db.Delete(l1OriginByL1HeightKey(l1Origin.L1BlockHeight, blockID)); err != nil {
+			log.Crit("Failed to delete L1Origin L1 block height index", "err", err)
+		}
+	}
 }
 
 // ReadL1Origin retrieves the given L2 block's L1Origin from database.
@@ -75,6 +150,13 @@ func WriteHeadL1Origin(db ethdb.KeyValueWriter, blockID *big.Int) {
 	}
 }
 
+// DeleteHeadL1Origin removes the last L1Origin pointer from database.
+func DeleteHeadL1Origin(db ethdb.KeyValueWriter) {
+	if err := db.Delete(headL1OriginKey); err != nil {
+		log.Crit("Failed to delete head L1Origin", "error", err)
+	}
+}
+
 // ReadHeadL1Origin retrieves the last L1Origin from database.
 func ReadHeadL1Origin(db ethdb.KeyValueReader) (*big.Int, error) {
 	data, _ := db.Get(headL1OriginKey)
@@ -90,3 +172,169 @@ func ReadHeadL1Origin(db ethdb.KeyValueReader) (*big.Int, error) {
 
 	return (*big.Int)(blockID), nil
 }
+
+// ReadL1OriginsByL1BlockHash retrieves the L1Origins of all L2 blocks derived
+// from the given L1 block hash, in ascending block ID order.
+func ReadL1OriginsByL1BlockHash(db ethdb.KeyValueStore, l1BlockHash common.Hash) ([]*L1Origin, error) {
+	return readIndexedL1Origins(db, append(l1OriginByL1HashPrefix, l1BlockHash.Bytes()...), func(l1Origin *L1Origin) bool {
+		return l1Origin.L1BlockHash == l1BlockHash
+	})
+}
+
+// ReadL1OriginsByL1BlockHeight retrieves the L1Origins of all L2 blocks derived
+// from the L1 block(s) at the given height, in ascending block ID order.
+func ReadL1OriginsByL1BlockHeight(db ethdb.KeyValueStore, l1BlockHeight *big.Int) ([]*L1Origin, error) {
+	return readIndexedL1Origins(db, append(l1OriginByL1HeightPrefix, encodeBlockNumber(l1BlockHeight.Uint64())...), func(l1Origin *L1Origin) bool {
+		return l1Origin.L1BlockHeight != nil && l1Origin.L1BlockHeight.Cmp(l1BlockHeight) == 0
+	})
+}
+
+// readIndexedL1Origins iterates the reverse index entries under the given prefix,
+// and resolves them to the L1Origins they are pointing at. Entries whose L1Origin
+// no longer matches are skipped.
+func readIndexedL1Origins(db ethdb.KeyValueStore, prefix []byte, match func(*L1Origin) bool) ([]*L1Origin, error) {
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var l1Origins []*L1Origin
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		blockID := new(big.Int).SetUint64(binary.BigEndian.Uint64(key[len(prefix):]))
+
+		l1Origin, err := ReadL1Origin(db, blockID)
+		if err != nil {
+			return nil, err
+		}
+		if l1Origin == nil || !match(l1Origin) {
+			continue
+		}
+		l1Origins = append(l1Origins, l1Origin)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	return l1Origins, nil
+}
+
+// IterateL1Origins iterates over all L1Origin records in the database, in no
+// particular order, until the given callback returns false.
+func IterateL1Origins(db ethdb.Iteratee, fn func(*L1Origin) bool) error {
+	it := db.NewIterator(l1OriginPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		l1Origin := new(L1Origin)
+		if err := rlp.DecodeBytes(it.Value(), l1Origin); err != nil {
+			return fmt.Errorf("invalid L1Origin RLP bytes at %x: %w", it.Key(), err)
+		}
+		if !fn(l1Origin) {
+			break
+		}
+	}
+
+	return it.Error()
+}
+
+// HasL1OriginIndexes returns whether the reverse indexes of the L1Origins written
+// before they were introduced were backfilled.
+func HasL1OriginIndexes(db ethdb.KeyValueReader) bool {
+	has, _ := db.Has(l1OriginIndexesKey)
+	return has
+}
+
+// BackfillL1OriginIndexes writes the L1 block hash and L1 block height reverse
+// index entries of all L1Origin records, and marks the indexes as complete. The
+// entries already present are rewritten as is. The number of indexed records is
+// returned.
+func BackfillL1OriginIndexes(db ethdb.KeyValueStore) (int, error) {
+	var (
+		batch    = db.NewBatch()
+		count    int
+		writeErr error
+	)
+	err := IterateL1Origins(db, func(l1Origin *L1Origin) bool {
+		writeL1OriginIndexes(batch, l1Origin.BlockID, l1Origin)
+		if count++; count%100_000 == 0 {
+			log.Info("Indexing L1Origins", "items", count)
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if writeErr = batch.Write(); writeErr != nil {
+				return false
+			}
+			batch.Reset()
+		}
+		return true
+	})
+	if err != nil {
+		return count, err
+	}
+	if writeErr != nil {
+		return count, writeErr
+	}
+	if err := batch.Put(l1OriginIndexesKey, []byte{1}); err != nil {
+		return count, err
+	}
+	return count, batch.Write()
+}
+
+// VerifyL1Origin checks whether the given L1Origin is consistent with the canonical
+// chain whose head is at the given height, i.e. its L2 block is not above the chain
+// head and is still canonical.
+func VerifyL1Origin(db ethdb.Reader, l1Origin *L1Origin, head uint64) error {
+	if !l1Origin.BlockID.IsUint64() || l1Origin.BlockID.Uint64() > head {
+		return fmt.Errorf("%w: blockID %v, head %d", errL1OriginAboveHead, l1Origin.BlockID, head)
+	}
+	if hash := ReadCanonicalHash(db, l1Origin.BlockID.Uint64()); hash != l1Origin.L2BlockHash {
+		return fmt.Errorf("%w: blockID %v, have %s, canonical %s", errL1OriginNotCanonical, l1Origin.BlockID, l1Origin.L2BlockHash, hash)
+	}
+	return nil
+}
+
+// RepairL1Origins atomically removes the given inconsistent L1Origin records, and
+// moves the head L1Origin pointer to the newest remaining record which is not above
+// the given chain head. The new head L1Origin pointer is returned, nil if there is
+// no L1Origin record left.
+func RepairL1Origins(db ethdb.KeyValueStore, stale []*L1Origin, head uint64) *big.Int {
+	removed := make(map[uint64]struct{}, len(stale))
+
+	batch := db.NewBatch()
+	for _, l1Origin := range stale {
+		DeleteL1Origin(batch, l1Origin)
+		removed[l1Origin.BlockID.Uint64()] = struct{}{}
+	}
+
+	headID, _ := ReadHeadL1Origin(db)
+	if headID != nil {
+		// Walk backwards from the current pointer until a remaining record is found.
+		newHeadID := head
+		if headID.IsUint64() && headID.Uint64() < newHeadID {
+			newHeadID = headID.Uint64()
+		}
+		for headID = nil; ; newHeadID-- {
+			if _, ok := removed[newHeadID]; !ok {
+				if l1Origin, _ := ReadL1Origin(db, new(big.Int).SetUint64(newHeadID)); l1Origin != nil {
+					headID = l1Origin.BlockID
+					break
+				}
+			}
+			if newHeadID == 0 {
+				break
+			}
+		}
+		if headID != nil {
+			WriteHeadL1Origin(batch, headID)
+		} else {
+			DeleteHeadL1Origin(batch)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to repair L1Origins", "err", err)
+	}
+
+	return headID
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -57,3 +58,132 @@ func TestHeadL1Origin(t *testing.T) {
 	require.NotNil(t, blockID)
 	assert.Equal(t, testBlockID, blockID)
 }
+
+func TestL1OriginsByL1Block(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		l1BlockHash   = randomHash()
+		l1BlockHeight = big.NewInt(100)
+	)
+	for i := int64(1); i <= 3; i++ {
+		WriteL1Origin(db, big.NewInt(i), &L1Origin{
+			BlockID:       big.NewInt(i),
+			L2BlockHash:   randomHash(),
+			L1BlockHeight: l1BlockHeight,
+			L1BlockHash:   l1BlockHash,
+		})
+	}
+	// A L2 block derived from another L1 block.
+	WriteL1Origin(db, big.NewInt(4), &L1Origin{
+		BlockID:       big.NewInt(4),
+		L2BlockHash:   randomHash(),
+		L1BlockHeight: big.NewInt(101),
+		L1BlockHash:   randomHash(),
+	})
+
+	l1Origins, err := ReadL1OriginsByL1BlockHash(db, l1BlockHash)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 3)
+	for i, l1Origin := range l1Origins {
+		assert.Equal(t, big.NewInt(int64(i+1)), l1Origin.BlockID)
+	}
+
+	l1Origins, err = ReadL1OriginsByL1BlockHeight(db, l1BlockHeight)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 3)
+
+	// Overwrite the L1Origin of block 3, the stale index entries should be gone.
+	reorgedHash := randomHash()
+	WriteL1Origin(db, big.NewInt(3), &L1Origin{
+		BlockID:       big.NewInt(3),
+		L2BlockHash:   randomHash(),
+		L1BlockHeight: big.NewInt(101),
+		L1BlockHash:   reorgedHash,
+	})
+
+	l1Origins, err = ReadL1OriginsByL1BlockHash(db, l1BlockHash)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 2)
+
+	l1Origins, err = ReadL1OriginsByL1BlockHeight(db, l1BlockHeight)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 2)
+
+	l1Origins, err = ReadL1OriginsByL1BlockHeight(db, big.NewInt(101))
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 2)
+	assert.Equal(t, reorgedHash, l1Origins[0].L1BlockHash)
+
+	l1Origins, err = ReadL1OriginsByL1BlockHash(db, randomHash())
+	require.Nil(t, err)
+	require.Empty(t, l1Origins)
+}
+
+func TestDeleteL1Origin(t *testing.T) {
+	db := NewMemoryDatabase()
+	testL1Origin := &L1Origin{
+		BlockID:       big.NewInt(1),
+		L2BlockHash:   randomHash(),
+		L1BlockHeight: big.NewInt(10),
+		L1BlockHash:   randomHash(),
+	}
+	WriteL1Origin(db, testL1Origin.BlockID, testL1Origin)
+	WriteHeadL1Origin(db, testL1Origin.BlockID)
+
+	DeleteL1Origin(db, testL1Origin)
+	DeleteHeadL1Origin(db)
+
+	l1Origin, err := ReadL1Origin(db, testL1Origin.BlockID)
+	require.Nil(t, err)
+	require.Nil(t, l1Origin)
+
+	blockID, err := ReadHeadL1Origin(db)
+	require.Nil(t, err)
+	require.Nil(t, blockID)
+
+	l1Origins, err := ReadL1OriginsByL1BlockHash(db, testL1Origin.L1BlockHash)
+	require.Nil(t, err)
+	require.Empty(t, l1Origins)
+}
+
+func TestBackfillL1OriginIndexes(t *testing.T) {
+	db := NewMemoryDatabase()
+	require.False(t, HasL1OriginIndexes(db))
+
+	// The L1Origins of a database predating the reverse indexes.
+	l1BlockHash := randomHash()
+	for i := int64(1); i <= 3; i++ {
+		data, err := rlp.EncodeToBytes(&L1Origin{
+			BlockID:       big.NewInt(i),
+			L2BlockHash:   randomHash(),
+			L1BlockHeight: big.NewInt(100),
+			L1BlockHash:   l1BlockHash,
+		})
+		require.Nil(t, err)
+		require.Nil(t, db.Put(l1OriginKey(big.NewInt(i)), data))
+	}
+	l1Origins, err := ReadL1OriginsByL1BlockHash(db, l1BlockHash)
+	require.Nil(t, err)
+	require.Empty(t, l1Origins)
+
+	count, err := BackfillL1OriginIndexes(db)
+	require.Nil(t, err)
+	require.Equal(t, 3, count)
+	require.True(t, HasL1OriginIndexes(db))
+
+	l1Origins, err = ReadL1OriginsByL1BlockHash(db, l1BlockHash)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 3)
+	l1Origins, err = ReadL1OriginsByL1BlockHeight(db, big.NewInt(100))
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 3)
+
+	// The marker isn't mistaken for a L1Origin record.
+	count = 0
+	require.Nil(t, IterateL1Origins(db, func(*L1Origin) bool {
+		count++
+		return true
+	}))
+	require.Equal(t, 3, count)
+}
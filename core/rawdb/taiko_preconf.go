@@ -0,0 +1,33 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Database key of the newest preconfirmed L2 block hash.
+var headPreconfBlockKey = []byte("TKO:LastPreconf")
+
+// ReadHeadPreconfBlockHash retrieves the hash of the newest preconfirmed L2 block.
+func ReadHeadPreconfBlockHash(db ethdb.KeyValueReader) common.Hash {
+	data, _ := db.Get(headPreconfBlockKey)
+	if len(data) == 0 {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteHeadPreconfBlockHash stores the hash of the newest preconfirmed L2 block.
+func WriteHeadPreconfBlockHash(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Put(headPreconfBlockKey, hash.Bytes()); err != nil {
+		log.Crit("Failed to store head preconfirmed block hash", "err", err)
+	}
+}
+
+// DeleteHeadPreconfBlockHash removes the hash of the newest preconfirmed L2 block.
+func DeleteHeadPreconfBlockHash(db ethdb.KeyValueWriter) {
+	if err := db.Delete(headPreconfBlockKey); err != nil {
+		log.Crit("Failed to delete head preconfirmed block hash", "err", err)
+	}
+}
@@ -0,0 +1,62 @@
+package rawdb
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Database key prefix for the transactions skipped while sealing a L2 block.
+var skippedTxsPrefix = []byte("TKO:SKP")
+
+// skippedTxsKey = skippedTxsPrefix + l2BlockHash
+func skippedTxsKey(blockHash common.Hash) []byte {
+	return append(skippedTxsPrefix, blockHash.Bytes()...)
+}
+
+//go:generate go run github.com/fjl/gencodec -type SkippedTransaction -field-override skippedTransactionMarshaling -out gen_taiko_skipped_tx.go
+
+// SkippedTransaction represents a transaction in a proposed L2 block's txList,
+// which was skipped while sealing the block.
+type SkippedTransaction struct {
+	Index  uint64      `json:"index" gencodec:"required"`
+	Hash   common.Hash `json:"hash" gencodec:"required"`
+	Reason string      `json:"reason" gencodec:"required"`
+}
+
+type skippedTransactionMarshaling struct {
+	Index hexutil.Uint64
+}
+
+// WriteSkippedTransactions stores the transactions skipped while sealing the
+// given L2 block into the database.
+func WriteSkippedTransactions(db ethdb.KeyValueWriter, blockHash common.Hash, txs []*SkippedTransaction) {
+	data, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		log.Crit("Failed to encode skipped transactions", "err", err)
+	}
+
+	if err := db.Put(skippedTxsKey(blockHash), data); err != nil {
+		log.Crit("Failed to store skipped transactions", "err", err)
+	}
+}
+
+// ReadSkippedTransactions retrieves the transactions skipped while sealing the
+// given L2 block from database.
+func ReadSkippedTransactions(db ethdb.KeyValueReader, blockHash common.Hash) ([]*SkippedTransaction, error) {
+	data, _ := db.Get(skippedTxsKey(blockHash))
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var txs []*SkippedTransaction
+	if err := rlp.DecodeBytes(data, &txs); err != nil {
+		return nil, fmt.Errorf("invalid skipped transactions RLP bytes: %w", err)
+	}
+
+	return txs, nil
+}
@@ -0,0 +1,26 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkippedTransactions(t *testing.T) {
+	db := NewMemoryDatabase()
+	blockHash := randomHash()
+
+	skipped, err := ReadSkippedTransactions(db, blockHash)
+	require.Nil(t, err)
+	require.Nil(t, skipped)
+
+	testSkipped := []*SkippedTransaction{
+		{Index: 0, Hash: randomHash(), Reason: "invalid sender"},
+		{Index: 3, Hash: randomHash(), Reason: "nonce too high"},
+	}
+	WriteSkippedTransactions(db, blockHash, testSkipped)
+
+	skipped, err = ReadSkippedTransactions(db, blockHash)
+	require.Nil(t, err)
+	require.Equal(t, testSkipped, skipped)
+}
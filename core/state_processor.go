@@ -36,8 +36,10 @@ import (
 // StateProcessor implements Processor.
 type StateProcessor struct {
 	config *params.ChainConfig // Chain configuration options
-	bc     *BlockChain         // Canonical block chain
-	engine consensus.Engine    // Consensus engine used for block rewards
+	// CHANGE(taiko): any chain able to execute a block, so that blocks can also be
+	// executed from a witness.
+	bc     executionChain   // Canonical block chain
+	engine consensus.Engine // Consensus engine used for block rewards
 }
 
 // NewStateProcessor initialises a new StateProcessor.
@@ -23,6 +23,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
@@ -406,10 +407,17 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.state.AddBalance(st.evm.Context.Coinbase, fee)
 		// CHANGE(taiko): basefee is not burnt, but sent to a treasury instead.
 		if st.evm.ChainConfig().Taiko && st.evm.Context.BaseFee != nil && !st.isAnchor() {
-			st.state.AddBalance(
-				st.evm.ChainConfig().Treasury,
-				new(big.Int).Mul(st.evm.Context.BaseFee, new(big.Int).SetUint64(st.gasUsed())),
-			)
+			baseFee := new(big.Int).Mul(st.evm.Context.BaseFee, new(big.Int).SetUint64(st.gasUsed()))
+			// Since Ontake, a part of the basefee is shared with the block proposer,
+			// its percentage is encoded in the block extra data.
+			if rules.IsOntake {
+				pctg := taiko.DecodeOntakeExtraData(st.evm.Context.Extra)
+				shared := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(uint64(pctg)))
+				shared.Div(shared, big.NewInt(100))
+				st.state.AddBalance(st.evm.Context.Coinbase, shared)
+				baseFee.Sub(baseFee, shared)
+			}
+			st.state.AddBalance(st.evm.ChainConfig().TaikoParams().BaseFeeRecipient(), baseFee)
 		}
 	}
 
@@ -445,5 +453,5 @@ func (st *StateTransition) gasUsed() uint64 {
 func (st *StateTransition) isAnchor() bool {
 	return st.evm.ChainConfig().Taiko &&
 		st.msg.IsFirstTx &&
-		st.msg.From == common.HexToAddress("0x0000777735367b36bC9B61C50022d9D0700dB4Ec")
+		st.msg.From == st.evm.ChainConfig().TaikoParams().AnchorSender
 }
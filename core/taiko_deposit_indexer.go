@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+const (
+	// depositThrottling is the time to wait between processing two consecutive
+	// deposit index sections.
+	depositThrottling = 10 * time.Millisecond
+)
+
+// TaikoDepositIndexer implements a core.ChainIndexer, indexing the L1 -> L2 ETH
+// deposits credited by the withdrawals of the canonical L2 blocks, by deposit
+// index and by recipient.
+type TaikoDepositIndexer struct {
+	db    ethdb.Database // database instance to write index data into
+	batch ethdb.Batch    // batch of the index entries of the section being processed
+}
+
+// NewTaikoDepositIndexer returns a chain indexer that indexes the deposits of the
+// canonical chain.
+func NewTaikoDepositIndexer(db ethdb.Database, size, confirms uint64) *ChainIndexer {
+	backend := &TaikoDepositIndexer{db: db}
+	table := rawdb.NewTable(db, string(rawdb.TaikoDepositIndexPrefix))
+
+	return NewChainIndexer(db, table, backend, size, confirms, depositThrottling, "deposits")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new deposit index section.
+//
+// The entries of a reorged section are overwritten while the section is indexed
+// again, the stale entries left behind are filtered out when reading the index.
+func (d *TaikoDepositIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	d.batch = d.db.NewBatch()
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the deposits of a new
+// block into the index.
+func (d *TaikoDepositIndexer) Process(ctx context.Context, header *types.Header) error {
+	if header.WithdrawalsHash == nil {
+		return nil
+	}
+	hash, number := header.Hash(), header.Number.Uint64()
+
+	body := rawdb.ReadBody(d.db, hash, number)
+	if body == nil {
+		return fmt.Errorf("block #%d [%x..] body not found", number, hash[:4])
+	}
+	for _, w := range body.Withdrawals {
+		rawdb.WriteTaikoDeposit(d.batch, &rawdb.TaikoDeposit{
+			Index:       w.Index,
+			Recipient:   w.Address,
+			Amount:      w.Amount,
+			BlockHash:   hash,
+			BlockNumber: number,
+		})
+	}
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, writing out the deposit index
+// section into the database.
+func (d *TaikoDepositIndexer) Commit() error {
+	return d.batch.Write()
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (d *TaikoDepositIndexer) Prune(threshold uint64) error {
+	return nil
+}
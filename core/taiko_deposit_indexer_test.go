@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaikoDepositIndexer(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		indexer = &TaikoDepositIndexer{db: db}
+		alice   = common.Address{0xaa}
+		bob     = common.Address{0xbb}
+		parent  = common.Hash{}
+	)
+	// newBlock writes a canonical block crediting the given deposits.
+	newBlock := func(number int64, extra byte, withdrawals ...*types.Withdrawal) *types.Header {
+		header := &types.Header{
+			ParentHash:      parent,
+			Number:          big.NewInt(number),
+			Extra:           []byte{extra},
+			WithdrawalsHash: &types.EmptyWithdrawalsHash,
+		}
+		block := types.NewBlockWithHeader(header).WithWithdrawals(withdrawals)
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		parent = block.Hash()
+		return block.Header()
+	}
+	index := func(headers ...*types.Header) {
+		require.Nil(t, indexer.Reset(context.Background(), 0, common.Hash{}))
+		for _, header := range headers {
+			require.Nil(t, indexer.Process(context.Background(), header))
+		}
+		require.Nil(t, indexer.Commit())
+	}
+	depositIndexes := func(recipient common.Address, start uint64, limit int) []uint64 {
+		deposits, err := rawdb.ReadTaikoDepositsByAddress(db, recipient, start, limit)
+		require.Nil(t, err)
+		indexes := make([]uint64, 0, len(deposits))
+		for _, deposit := range deposits {
+			require.Equal(t, recipient, deposit.Recipient)
+			indexes = append(indexes, deposit.Index)
+		}
+		return indexes
+	}
+
+	genesis := newBlock(0, 0)
+	genesis.WithdrawalsHash = nil
+	block1 := newBlock(1, 0,
+		&types.Withdrawal{Index: 0, Address: alice, Amount: 1},
+		&types.Withdrawal{Index: 1, Address: bob, Amount: 2},
+	)
+	block2 := newBlock(2, 0)
+	block3 := newBlock(3, 0,
+		&types.Withdrawal{Index: 2, Address: alice, Amount: 3},
+		&types.Withdrawal{Index: 3, Address: alice, Amount: 4},
+	)
+	index(genesis, block1, block2, block3)
+
+	require.Equal(t, []uint64{0, 2, 3}, depositIndexes(alice, 0, 10))
+	require.Equal(t, []uint64{2}, depositIndexes(alice, 1, 1))
+	require.Equal(t, []uint64{1}, depositIndexes(bob, 0, 10))
+
+	deposit, err := rawdb.ReadTaikoDeposit(db, 2)
+	require.Nil(t, err)
+	require.Equal(t, &rawdb.TaikoDeposit{Index: 2, Recipient: alice, Amount: 3, BlockHash: block3.Hash(), BlockNumber: 3}, deposit)
+
+	// Reorg block #3, the deposit #2 now credits bob and the deposit #3 is gone.
+	parent = block2.Hash()
+	index(newBlock(3, 1, &types.Withdrawal{Index: 2, Address: bob, Amount: 3}))
+
+	require.Equal(t, []uint64{0}, depositIndexes(alice, 0, 10))
+	require.Equal(t, []uint64{1, 2}, depositIndexes(bob, 0, 10))
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The fee metrics are counted in gwei, to fit into the int64 counters. They are
+// registered whatever the metrics switch, so that they can be read back from the
+// registry, but only updated if metrics are enabled.
+var (
+	feeProposerTipsCounter    = metrics.NewRegisteredCounterForced("chain/taiko/fees/proposertips", nil)
+	feeSharedBaseFeeCounter   = metrics.NewRegisteredCounterForced("chain/taiko/fees/sharedbasefee", nil)
+	feeTreasuryBaseFeeCounter = metrics.NewRegisteredCounterForced("chain/taiko/fees/treasurybasefee", nil)
+	feeAnchorGasCounter       = metrics.NewRegisteredCounterForced("chain/taiko/fees/anchorgas", nil)
+)
+
+// CalcTaikoFeeStats accounts the fees paid by the transactions of the given L2
+// block, the same way they are credited by the state transition:
+//
+//   - the priority fees are credited to the block proposer.
+//   - the base fees are credited to the treasury, since Ontake a part of them is
+//     shared with the block proposer.
+//   - the anchor transaction, the first transaction of a L2 block sent by the
+//     anchor sender, pays no base fee.
+//
+// The gas used by the transactions is derived from the cumulative gas used of the
+// receipts, which is available even for the receipts synced from peers.
+func CalcTaikoFeeStats(config *params.ChainConfig, block *types.Block, receipts types.Receipts) *rawdb.TaikoFeeStats {
+	stats := &rawdb.TaikoFeeStats{
+		BlockHash:       block.Hash(),
+		BlockNumber:     block.NumberU64(),
+		ProposerTips:    new(big.Int),
+		SharedBaseFee:   new(big.Int),
+		TreasuryBaseFee: new(big.Int),
+	}
+	baseFee := block.BaseFee()
+	if baseFee == nil {
+		return stats
+	}
+
+	var sharingPctg uint8
+	if config.IsOntake(block.Number()) {
+		sharingPctg = taiko.DecodeOntakeExtraData(block.Extra())
+	}
+
+	var (
+		signer            = types.MakeSigner(config, block.Number())
+		cumulativeGasUsed uint64
+	)
+	for i, tx := range block.Transactions() {
+		if i >= len(receipts) {
+			break
+		}
+		gasUsed := new(big.Int).SetUint64(receipts[i].CumulativeGasUsed - cumulativeGasUsed)
+		cumulativeGasUsed = receipts[i].CumulativeGasUsed
+
+		tip := cmath.BigMin(tx.GasTipCap(), new(big.Int).Sub(tx.GasFeeCap(), baseFee))
+		stats.ProposerTips.Add(stats.ProposerTips, tip.Mul(tip, gasUsed))
+
+		if i == 0 && isAnchorTx(config, signer, tx) {
+			stats.AnchorGasUsed = gasUsed.Uint64()
+			continue
+		}
+		fee := new(big.Int).Mul(baseFee, gasUsed)
+		shared := new(big.Int).Mul(fee, new(big.Int).SetUint64(uint64(sharingPctg)))
+		shared.Div(shared, big.NewInt(100))
+
+		stats.SharedBaseFee.Add(stats.SharedBaseFee, shared)
+		stats.TreasuryBaseFee.Add(stats.TreasuryBaseFee, fee.Sub(fee, shared))
+	}
+
+	return stats
+}
+
+// isAnchorTx returns whether the given first transaction of a L2 block is the
+// anchor transaction.
+func isAnchorTx(config *params.ChainConfig, signer types.Signer, tx *types.Transaction) bool {
+	sender, err := types.Sender(signer, tx)
+	return err == nil && sender == config.TaikoParams().AnchorSender
+}
+
+// GetTaikoFeeStats retrieves the fee accounting of the given L2 block. The fee
+// accounting of the blocks which were not executed locally (e.g. snap synced) is
+// derived from their receipts.
+func (bc *BlockChain) GetTaikoFeeStats(hash common.Hash, number uint64) (*rawdb.TaikoFeeStats, error) {
+	stats, err := rawdb.ReadTaikoFeeStats(bc.db, hash)
+	if err != nil || stats != nil {
+		return stats, err
+	}
+	block := bc.GetBlock(hash, number)
+	if block == nil {
+		return nil, nil
+	}
+	receipts := bc.GetReceiptsByHash(hash)
+	if receipts == nil && block.Transactions().Len() > 0 {
+		return nil, nil
+	}
+
+	return CalcTaikoFeeStats(bc.chainConfig, block, receipts), nil
+}
+
+// updateTaikoFeeMetrics adds the fee accounting of a block becoming canonical to
+// the fee metrics, or subtracts it if the block is reorged out of the canonical
+// chain.
+func (bc *BlockChain) updateTaikoFeeMetrics(block *types.Block, canonical bool) {
+	stats, err := bc.GetTaikoFeeStats(block.Hash(), block.NumberU64())
+	if err != nil || stats == nil {
+		log.Debug("Missing taiko fee stats", "number", block.Number(), "hash", block.Hash(), "err", err)
+		return
+	}
+	sign := int64(1)
+	if !canonical {
+		sign = -1
+	}
+	feeProposerTipsCounter.Inc(sign * toGwei(stats.ProposerTips))
+	feeSharedBaseFeeCounter.Inc(sign * toGwei(stats.SharedBaseFee))
+	feeTreasuryBaseFeeCounter.Inc(sign * toGwei(stats.TreasuryBaseFee))
+	feeAnchorGasCounter.Inc(sign * int64(stats.AnchorGasUsed))
+}
+
+// toGwei converts the given wei amount to gwei, rounded down.
+func toGwei(wei *big.Int) int64 {
+	return new(big.Int).Div(wei, big.NewInt(params.GWei)).Int64()
+}
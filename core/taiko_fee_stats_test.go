@@ -0,0 +1,94 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaikoFeeStats(t *testing.T) {
+	var (
+		goldenTouchKey, _ = crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
+		key, _            = crypto.GenerateKey()
+		sender            = crypto.PubkeyToAddress(key.PublicKey)
+		treasury          = common.HexToAddress("0x01")
+		proposer          = common.HexToAddress("0x02")
+		config            = *params.TestChainConfig
+		db                = rawdb.NewMemoryDatabase()
+	)
+	config.Taiko = true
+	config.OntakeBlock = common.Big1
+	config.TaikoConfig = &params.TaikoConfig{
+		AnchorSender:   params.GoldenTouchAccount,
+		L2Contract:     params.TaikoL2Address,
+		Treasury:       treasury,
+		AnchorGasLimit: params.AnchorGasLimit,
+	}
+
+	genesis := &Genesis{
+		Config:  &config,
+		Alloc:   GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	signer := types.LatestSigner(&config)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, ethash.NewFaker(), 1, func(i int, b *BlockGen) {
+		b.SetCoinbase(proposer)
+		b.SetExtra(taiko.EncodeOntakeExtraData(25))
+		b.AddTx(types.MustSignNewTx(goldenTouchKey, signer, &types.LegacyTx{
+			To:       &params.TaikoL2Address,
+			Gas:      params.AnchorGasLimit,
+			GasPrice: b.BaseFee(),
+		}))
+		b.AddTx(types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			To:        &common.Address{0xaa},
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: new(big.Int).Add(b.BaseFee(), big.NewInt(2*params.GWei)),
+		}))
+	})
+
+	chain, err := NewBlockChain(db, nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.Nil(t, err)
+
+	// The fee accounting is stored alongside the receipts of the block.
+	block := blocks[0]
+	stats, err := rawdb.ReadTaikoFeeStats(db, block.Hash())
+	require.Nil(t, err)
+	require.NotNil(t, stats)
+	require.Equal(t, block.NumberU64(), stats.BlockNumber)
+	require.Equal(t, chain.GetReceiptsByHash(block.Hash())[0].GasUsed, stats.AnchorGasUsed)
+
+	// 25% of the basefee goes to the block proposer, the rest to the treasury.
+	baseFee := new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(params.TxGas))
+	shared := new(big.Int).Div(baseFee, big.NewInt(4))
+	require.Equal(t, new(big.Int).Mul(big.NewInt(params.GWei), new(big.Int).SetUint64(params.TxGas)), stats.ProposerTips)
+	require.Equal(t, shared, stats.SharedBaseFee)
+	require.Equal(t, new(big.Int).Sub(baseFee, shared), stats.TreasuryBaseFee)
+
+	// The accounting matches the balances credited by the state transition, the
+	// block proposer also receives the ethash block reward.
+	state, err := chain.State()
+	require.Nil(t, err)
+	require.Equal(t, stats.TreasuryBaseFee, state.GetBalance(treasury))
+	require.Equal(t, stats.ProposerFees(), new(big.Int).Sub(state.GetBalance(proposer), ethash.ConstantinopleBlockReward))
+
+	// The accounting of the blocks without stored fee stats is derived from
+	// their receipts.
+	db.Delete(append([]byte("TKO:FEE"), block.Hash().Bytes()...))
+	derived, err := chain.GetTaikoFeeStats(block.Hash(), block.NumberU64())
+	require.Nil(t, err)
+	require.Equal(t, stats, derived)
+}
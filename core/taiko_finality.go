@@ -0,0 +1,112 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SetL1Finality derives the safe and finalized L2 blocks from the L1Origins of the
+// canonical chain, given the height of the newest finalized L1 block:
+//
+//   - the safe block is the newest block derived from L1, preconfirmed blocks which
+//     are not included on L1 yet are not safe.
+//   - the finalized block is the newest block derived from a finalized L1 block.
+//
+// The finalized block never moves backwards, blocks without L1Origin (e.g. the
+// ones synced from peers) are covered by the newest L1 derived block above them.
+//
+// The safe block is searched from the head L1Origin down, and the finalized block
+// from the previous finalized block up, resuming where the previous call stopped,
+// so that each call only reads the L1Origins of the newly derived blocks.
+func (bc *BlockChain) SetL1Finality(l1Finalized uint64) error {
+	headID, err := rawdb.ReadHeadL1Origin(bc.db)
+	if err != nil {
+		return err
+	}
+	if headID == nil {
+		return nil
+	}
+
+	var (
+		finalized = bc.CurrentFinalBlock()
+		lowest    uint64
+		safe      *types.Header
+	)
+	if finalized != nil {
+		lowest = finalized.Number.Uint64()
+	}
+	for number := headID.Uint64(); number > lowest && safe == nil; number-- {
+		l1Origin, header, err := bc.derivedBlock(number)
+		if err != nil {
+			return err
+		}
+		if l1Origin != nil {
+			safe = header
+		}
+	}
+	if safe == nil {
+		safe = finalized
+	}
+	if safe == nil {
+		log.Debug("Updated L2 finality from L1", "l1Finalized", l1Finalized, "safe", nil, "finalized", nil)
+		return nil
+	}
+	bc.SetSafe(safe)
+
+	// The blocks below the cursor were already checked by a previous call, they're
+	// either finalized or not derived from L1. The cursor is reset if the chain was
+	// rewound below it.
+	next := bc.l1FinalityCursor.Load()
+	if next <= lowest || next > safe.Number.Uint64()+1 {
+		next = lowest + 1
+	}
+	for ; next <= safe.Number.Uint64(); next++ {
+		l1Origin, header, err := bc.derivedBlock(next)
+		if err != nil {
+			return err
+		}
+		if l1Origin == nil {
+			continue
+		}
+		if l1Origin.L1BlockHeight.Uint64() > l1Finalized {
+			break
+		}
+		finalized = header
+	}
+	bc.l1FinalityCursor.Store(next)
+
+	if finalized != nil && finalized.Number.Uint64() > lowest {
+		bc.SetFinalized(finalized)
+	}
+
+	log.Debug("Updated L2 finality from L1", "l1Finalized", l1Finalized, "safe", numberOf(safe), "finalized", numberOf(finalized))
+	return nil
+}
+
+// derivedBlock returns the L1Origin and the header of the given canonical block,
+// if it was derived from L1, or nils otherwise.
+func (bc *BlockChain) derivedBlock(number uint64) (*rawdb.L1Origin, *types.Header, error) {
+	l1Origin, err := rawdb.ReadL1Origin(bc.db, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, nil, err
+	}
+	if l1Origin == nil || l1Origin.IsPreconfirmed || l1Origin.L1BlockHeight == nil {
+		return nil, nil, nil
+	}
+	header := bc.GetHeaderByNumber(number)
+	if header == nil || header.Hash() != l1Origin.L2BlockHash {
+		return nil, nil, nil
+	}
+	return l1Origin, header, nil
+}
+
+// numberOf returns the number of the given header, nil if there's no header.
+func numberOf(header *types.Header) *big.Int {
+	if header == nil {
+		return nil
+	}
+	return header.Number
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetL1Finality(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{Config: &config, BaseFee: common.Big1}
+	)
+	config.Taiko = true
+
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 5, nil)
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.Nil(t, err)
+
+	// Nothing is derived from L1 yet.
+	require.Nil(t, chain.SetL1Finality(100))
+	require.Nil(t, chain.CurrentSafeBlock())
+	require.Nil(t, chain.CurrentFinalBlock())
+
+	// Blocks #1 - #4 are derived from L1, block #5 is preconfirmed.
+	for i, l1Height := range []int64{10, 10, 11, 12, 0} {
+		l1Origin := &rawdb.L1Origin{
+			BlockID:        blocks[i].Number(),
+			L2BlockHash:    blocks[i].Hash(),
+			L1BlockHeight:  big.NewInt(l1Height),
+			IsPreconfirmed: i == 4,
+		}
+		rawdb.WriteL1Origin(db, l1Origin.BlockID, l1Origin)
+		if !l1Origin.IsPreconfirmed {
+			rawdb.WriteHeadL1Origin(db, l1Origin.BlockID)
+		}
+	}
+
+	require.Nil(t, chain.SetL1Finality(9))
+	require.Equal(t, blocks[3].Hash(), chain.CurrentSafeBlock().Hash())
+	require.Nil(t, chain.CurrentFinalBlock())
+
+	require.Nil(t, chain.SetL1Finality(10))
+	require.Equal(t, blocks[3].Hash(), chain.CurrentSafeBlock().Hash())
+	require.Equal(t, blocks[1].Hash(), chain.CurrentFinalBlock().Hash())
+
+	require.Nil(t, chain.SetL1Finality(11))
+	require.Equal(t, blocks[2].Hash(), chain.CurrentFinalBlock().Hash())
+
+	// The finalized block never moves backwards.
+	require.Nil(t, chain.SetL1Finality(10))
+	require.Equal(t, blocks[2].Hash(), chain.CurrentFinalBlock().Hash())
+
+	// Preconfirmed blocks are never final.
+	require.Nil(t, chain.SetL1Finality(100))
+	require.Equal(t, blocks[3].Hash(), chain.CurrentSafeBlock().Hash())
+	require.Equal(t, blocks[3].Hash(), chain.CurrentFinalBlock().Hash())
+}
+
+// l1OriginReadCounter counts the L1Origin reads of a database.
+type l1OriginReadCounter struct {
+	ethdb.Database
+	reads int
+}
+
+func (db *l1OriginReadCounter) Get(key []byte) ([]byte, error) {
+	if bytes.HasPrefix(key, []byte("TKO:L1O")) {
+		db.reads++
+	}
+	return db.Database.Get(key)
+}
+
+func TestSetL1FinalityIncremental(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{Config: &config, BaseFee: common.Big1}
+	)
+	config.Taiko = true
+
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 64, nil)
+
+	db := &l1OriginReadCounter{Database: rawdb.NewMemoryDatabase()}
+	chain, err := NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.Nil(t, err)
+
+	// Block #n is derived from L1 block 100 + n.
+	for _, block := range blocks {
+		rawdb.WriteL1Origin(db, block.Number(), &rawdb.L1Origin{
+			BlockID:       block.Number(),
+			L2BlockHash:   block.Hash(),
+			L1BlockHeight: new(big.Int).Add(big.NewInt(100), block.Number()),
+		})
+		rawdb.WriteHeadL1Origin(db, block.Number())
+	}
+
+	// Nothing is finalized yet, the chain is only read up to the first block which
+	// isn't final.
+	db.reads = 0
+	require.Nil(t, chain.SetL1Finality(99))
+	require.Nil(t, chain.CurrentFinalBlock())
+	require.Equal(t, blocks[63].Hash(), chain.CurrentSafeBlock().Hash())
+	require.LessOrEqual(t, db.reads, 2)
+
+	require.Nil(t, chain.SetL1Finality(132))
+	require.Equal(t, blocks[31].Hash(), chain.CurrentFinalBlock().Hash())
+
+	// Further calls only read the newly finalized blocks.
+	db.reads = 0
+	require.Nil(t, chain.SetL1Finality(132))
+	require.Equal(t, blocks[31].Hash(), chain.CurrentFinalBlock().Hash())
+	require.LessOrEqual(t, db.reads, 2)
+
+	db.reads = 0
+	require.Nil(t, chain.SetL1Finality(140))
+	require.Equal(t, blocks[39].Hash(), chain.CurrentFinalBlock().Hash())
+	require.LessOrEqual(t, db.reads, 10)
+
+	// The cursor is reset when the chain is rewound below it.
+	require.Nil(t, chain.SetHead(20))
+	rawdb.WriteHeadL1Origin(db, big.NewInt(20))
+	require.Nil(t, chain.SetL1Finality(115))
+	require.Equal(t, blocks[14].Hash(), chain.CurrentFinalBlock().Hash())
+	require.Equal(t, blocks[19].Hash(), chain.CurrentSafeBlock().Hash())
+}
@@ -1,55 +1,54 @@
 package core
 
 import (
-	"math/big"
-
 	"github.com/ethereum/go-ethereum/common"
-	taikoGenesis "github.com/ethereum/go-ethereum/core/taiko_genesis"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// TaikoGenesisBlock returns the Taiko network genesis block configs.
-func TaikoGenesisBlock(networkID uint64) *Genesis {
-	chainConfig := params.TaikoChainConfig
+// taikoL2Contracts is the known addresses of the predeployed TaikoL2 contract,
+// in order of preference.
+var taikoL2Contracts = []common.Address{params.TaikoL2Address, params.TaikoL2LegacyAddress}
 
-	var allocJSON []byte
-	switch networkID {
-	case params.TaikoInternal1NetworkID.Uint64():
-		chainConfig.ChainID = params.TaikoInternal1NetworkID
-		allocJSON = taikoGenesis.Internal1GenesisAllocJSON
-	case params.TaikoInternal2NetworkID.Uint64():
-		chainConfig.ChainID = params.TaikoInternal2NetworkID
-		allocJSON = taikoGenesis.Internal2GenesisAllocJSON
-	case params.SnæfellsjökullNetworkID.Uint64():
-		chainConfig.ChainID = params.SnæfellsjökullNetworkID
-		allocJSON = taikoGenesis.SnæfellsjökullGenesisAllocJSON
-	case params.AskjaNetworkID.Uint64():
-		chainConfig.ChainID = params.AskjaNetworkID
-		allocJSON = taikoGenesis.AskjaGenesisAllocJSON
-	case params.GrimsvotnNetworkID.Uint64():
-		chainConfig.ChainID = params.GrimsvotnNetworkID
-		allocJSON = taikoGenesis.GrimsvotnGenesisAllocJSON
-	case params.EldfellNetworkID.Uint64():
-		chainConfig.ChainID = params.EldfellNetworkID
-		allocJSON = taikoGenesis.EldfellGenesisAllocJSON
-	default:
-		chainConfig.ChainID = params.TaikoMainnetNetworkID
-		allocJSON = taikoGenesis.MainnetGenesisAllocJSON
+// TaikoGenesisBlock returns the genesis block of the built-in Taiko network with
+// the given network ID, or of the Taiko mainnet if the network ID is unknown.
+func TaikoGenesisBlock(networkID uint64) *Genesis {
+	registry, err := NewTaikoNetworkRegistry()
+	if err != nil {
+		log.Crit("Failed to create taiko network registry", "err", err)
 	}
+	network, err := registry.Resolve("", networkID)
+	if err != nil {
+		log.Crit("Failed to load taiko network", "networkID", networkID, "err", err)
+	}
+	return network.Genesis()
+}
 
-	var alloc GenesisAlloc
-	if err := alloc.UnmarshalJSON(allocJSON); err != nil {
-		log.Crit("unmarshal alloc json error", "error", err)
+// deriveTaikoL2Contract sets the TaikoL2 contract of a Taiko genesis to the one
+// predeployed by its allocation, if the contract of its chain config isn't, so
+// that the anchor transactions of the networks predating the TaikoL2 proxy are
+// verified against the right contract.
+func (g *Genesis) deriveTaikoL2Contract() {
+	if g.Config == nil || !g.Config.Taiko || len(g.Alloc) == 0 {
+		return
+	}
+	taikoConfig := g.Config.TaikoParams()
+	if account, ok := g.Alloc[taikoConfig.L2Contract]; ok && len(account.Code) > 0 {
+		return
 	}
+	for _, contract := range taikoL2Contracts {
+		if account, ok := g.Alloc[contract]; ok && len(account.Code) > 0 {
+			// The chain config may be shared, update a copy of it.
+			var (
+				config         = *g.Config
+				taikoConfigCpy = *taikoConfig
+			)
+			taikoConfigCpy.L2Contract = contract
+			config.TaikoConfig = &taikoConfigCpy
+			g.Config = &config
 
-	return &Genesis{
-		Config:     chainConfig,
-		ExtraData:  []byte{},
-		GasLimit:   uint64(6000000),
-		Difficulty: common.Big0,
-		Alloc:      alloc,
-		GasUsed:    0,
-		BaseFee:    new(big.Int).SetUint64(10000000),
+			log.Info("Derived TaikoL2 contract from genesis alloc", "address", contract)
+			return
+		}
 	}
 }
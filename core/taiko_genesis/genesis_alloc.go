@@ -1,7 +1,7 @@
 package taiko_genesis
 
 import (
-	_ "embed"
+	"embed"
 )
 
 //go:embed mainnet.json
@@ -24,3 +24,9 @@ var GrimsvotnGenesisAllocJSON []byte
 
 //go:embed eldfell.json
 var EldfellGenesisAllocJSON []byte
+
+// Networks contains the definitions of the built-in Taiko networks under
+// `networks/`, along with the genesis allocations they refer to.
+//
+//go:embed *.json networks/*.json
+var Networks embed.FS
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// repairL1Origins removes the L1Origin records which became inconsistent with the
+// canonical chain after a rewind or a reorg, i.e. the ones above the new chain head
+// or whose L2 block is no longer canonical, and moves the head L1Origin pointer
+// accordingly. Only the L2 blocks above the given common ancestor are checked.
+//
+// Note, this function assumes that the chain mutex is held.
+func (bc *BlockChain) repairL1Origins(ancestor uint64, oldHead uint64) {
+	var (
+		head = bc.CurrentBlock().Number.Uint64()
+		last = oldHead
+	)
+	if head > last {
+		last = head
+	}
+	headID, err := rawdb.ReadHeadL1Origin(bc.db)
+	if err != nil {
+		log.Error("Failed to read head L1Origin", "err", err)
+		return
+	}
+	if headID == nil {
+		return // Not a L1 derived chain
+	}
+	if headID.IsUint64() && headID.Uint64() > last {
+		last = headID.Uint64()
+	}
+
+	var stale []*rawdb.L1Origin
+	for id := ancestor + 1; id <= last; id++ {
+		l1Origin, err := rawdb.ReadL1Origin(bc.db, new(big.Int).SetUint64(id))
+		if err != nil {
+			log.Error("Failed to read L1Origin", "blockID", id, "err", err)
+			continue
+		}
+		if l1Origin == nil {
+			continue
+		}
+		if err := rawdb.VerifyL1Origin(bc.db, l1Origin, head); err != nil {
+			log.Debug("Inconsistent L1Origin", "blockID", id, "reason", err)
+			stale = append(stale, l1Origin)
+		}
+	}
+	if len(stale) == 0 && headID.Cmp(new(big.Int).SetUint64(head)) <= 0 {
+		return
+	}
+	newHeadID := rawdb.RepairL1Origins(bc.db, stale, head)
+
+	log.Info("Removed inconsistent L1Origins", "count", len(stale), "head", head, "headL1Origin", newHeadID)
+}
+
+// canonicalAncestor returns the number of the newest block in the ancestry of the
+// given header which is part of the current canonical chain.
+func (bc *BlockChain) canonicalAncestor(header *types.Header) uint64 {
+	for header != nil && header.Number.Sign() > 0 {
+		if bc.GetCanonicalHash(header.Number.Uint64()) == header.Hash() {
+			return header.Number.Uint64()
+		}
+		header = bc.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	return 0
+}
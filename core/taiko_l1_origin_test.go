@@ -0,0 +1,128 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaikoTestChain(t *testing.T, n int) (*BlockChain, *Genesis, []*types.Block) {
+	config := *params.TestChainConfig
+	config.Taiko = true
+
+	genesis := &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}
+	_, blocks, _ := GenerateChainWithGenesis(genesis, ethash.NewFaker(), n, func(i int, b *BlockGen) {})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	t.Cleanup(chain.Stop)
+
+	_, err = chain.InsertChain(blocks)
+	require.Nil(t, err)
+
+	for i, block := range blocks {
+		rawdb.WriteL1Origin(chain.db, block.Number(), &rawdb.L1Origin{
+			BlockID:       block.Number(),
+			L2BlockHash:   block.Hash(),
+			L1BlockHeight: big.NewInt(int64(100 + i)),
+			L1BlockHash:   common.BigToHash(big.NewInt(int64(100 + i))),
+		})
+		rawdb.WriteHeadL1Origin(chain.db, block.Number())
+	}
+
+	return chain, genesis, blocks
+}
+
+func TestSetHeadRemovesL1Origins(t *testing.T) {
+	chain, _, _ := newTaikoTestChain(t, 5)
+
+	require.Nil(t, chain.SetHead(2))
+
+	for id := int64(1); id <= 5; id++ {
+		l1Origin, err := rawdb.ReadL1Origin(chain.db, big.NewInt(id))
+		require.Nil(t, err)
+		if id <= 2 {
+			require.NotNil(t, l1Origin)
+		} else {
+			require.Nil(t, l1Origin)
+		}
+	}
+
+	headID, err := rawdb.ReadHeadL1Origin(chain.db)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(2), headID)
+}
+
+func TestReorgRemovesL1Origins(t *testing.T) {
+	chain, genesis, _ := newTaikoTestChain(t, 3)
+
+	// Fork the chain right after block 1.
+	_, forks, _ := GenerateChainWithGenesis(genesis, ethash.NewFaker(), 3, func(i int, b *BlockGen) {
+		if i > 0 {
+			b.SetExtra([]byte("fork"))
+		}
+	})
+	for _, block := range forks[1:] {
+		require.Nil(t, chain.InsertBlockWithoutSetHead(block))
+	}
+	_, err := chain.SetCanonical(forks[2])
+	require.Nil(t, err)
+
+	l1Origin, err := rawdb.ReadL1Origin(chain.db, common.Big1)
+	require.Nil(t, err)
+	require.NotNil(t, l1Origin)
+
+	for id := int64(2); id <= 3; id++ {
+		l1Origin, err := rawdb.ReadL1Origin(chain.db, big.NewInt(id))
+		require.Nil(t, err)
+		require.Nil(t, l1Origin)
+	}
+
+	headID, err := rawdb.ReadHeadL1Origin(chain.db)
+	require.Nil(t, err)
+	require.Equal(t, common.Big1, headID)
+}
+
+func TestStartupIndexesL1Origins(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		db      = rawdb.NewMemoryDatabase()
+		genesis = &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}
+		l1Hash  = common.Hash{0x01}
+	)
+	config.Taiko = true
+
+	chain, err := NewBlockChain(db, nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	chain.Stop()
+	require.True(t, rawdb.HasL1OriginIndexes(db))
+
+	// Turn the database into one predating the reverse indexes.
+	rawdb.WriteL1Origin(db, common.Big1, &rawdb.L1Origin{BlockID: common.Big1, L1BlockHeight: big.NewInt(100), L1BlockHash: l1Hash})
+	for _, prefix := range []string{"TKO:L1H", "TKO:L1N", "TKO:IndexedL1O"} {
+		it := db.NewIterator([]byte(prefix), nil)
+		for it.Next() {
+			require.Nil(t, db.Delete(it.Key()))
+		}
+		it.Release()
+	}
+	l1Origins, err := rawdb.ReadL1OriginsByL1BlockHash(db, l1Hash)
+	require.Nil(t, err)
+	require.Empty(t, l1Origins)
+
+	chain, err = NewBlockChain(db, nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	defer chain.Stop()
+
+	l1Origins, err = rawdb.ReadL1OriginsByL1BlockHash(db, l1Hash)
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 1)
+	require.True(t, rawdb.HasL1OriginIndexes(db))
+}
@@ -0,0 +1,268 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	taikoGenesis "github.com/ethereum/go-ethereum/core/taiko_genesis"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// defaultTaikoGasLimit is the genesis gas limit of a Taiko network definition
+	// which doesn't specify one.
+	defaultTaikoGasLimit = 6_000_000
+
+	// defaultTaikoBaseFee is the genesis base fee of a Taiko network definition
+	// which doesn't specify one.
+	defaultTaikoBaseFee = 10_000_000
+
+	// DefaultTaikoNetwork is the name of the network used when neither a network
+	// name nor a known network ID is given.
+	DefaultTaikoNetwork = "mainnet"
+)
+
+var (
+	ErrTaikoNetworkNotFound = errors.New("taiko network not found")
+	ErrTaikoNetworkExists   = errors.New("taiko network already registered")
+)
+
+// TaikoNetwork is the definition of a Taiko network.
+//
+// The chain config of a definition is applied on top of params.TaikoChainConfig,
+// so that a definition only needs to list the settings which differ from it,
+// usually the chain ID and a part of the Taiko protocol settings. The genesis
+// allocation is either given inline in `alloc`, or read from `allocFile`, which
+// is relative to the directory of the definition.
+type TaikoNetwork struct {
+	Name      string              `json:"name"`
+	Config    *params.ChainConfig `json:"config"`
+	Alloc     GenesisAlloc        `json:"alloc,omitempty"`
+	AllocFile string              `json:"allocFile,omitempty"`
+	GasLimit  uint64              `json:"gasLimit"`
+	BaseFee   *big.Int            `json:"baseFee"`
+	Bootnodes []string            `json:"bootnodes,omitempty"`
+}
+
+// Genesis returns the genesis block specification of the network.
+func (n *TaikoNetwork) Genesis() *Genesis {
+	return &Genesis{
+		Config:     n.Config,
+		ExtraData:  []byte{},
+		GasLimit:   n.GasLimit,
+		Difficulty: common.Big0,
+		Alloc:      n.Alloc,
+		GasUsed:    0,
+		BaseFee:    n.BaseFee,
+	}
+}
+
+// copy returns a deep copy of the network definition, so that callers are free
+// to modify the returned chain config and allocation.
+func (n *TaikoNetwork) copy() (*TaikoNetwork, error) {
+	blob, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	cpy := new(TaikoNetwork)
+	if err := json.Unmarshal(blob, cpy); err != nil {
+		return nil, err
+	}
+	return cpy, nil
+}
+
+// TaikoNetworkRegistry holds the known Taiko network definitions, indexed by name
+// and by chain ID.
+type TaikoNetworkRegistry struct {
+	networks map[string]*TaikoNetwork
+	ids      map[uint64]string
+	lock     sync.RWMutex
+}
+
+// NewTaikoNetworkRegistry creates a new registry, preloaded with the built-in
+// Taiko networks.
+func NewTaikoNetworkRegistry() (*TaikoNetworkRegistry, error) {
+	r := &TaikoNetworkRegistry{
+		networks: make(map[string]*TaikoNetwork),
+		ids:      make(map[uint64]string),
+	}
+	if err := r.load(taikoGenesis.Networks, "networks/*.json"); err != nil {
+		return nil, fmt.Errorf("failed to load built-in taiko networks: %w", err)
+	}
+	return r, nil
+}
+
+// LoadDir loads all the network definitions (`*.json`) in the given directory
+// into the registry. A definition can't replace an already registered network.
+func (r *TaikoNetworkRegistry) LoadDir(dir string) error {
+	if err := r.load(os.DirFS(dir), "*.json"); err != nil {
+		return fmt.Errorf("failed to load taiko networks from %s: %w", dir, err)
+	}
+	return nil
+}
+
+// load parses all the network definitions in fsys matching the given pattern.
+func (r *TaikoNetworkRegistry) load(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		network, err := parseTaikoNetwork(fsys, file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if err := r.Register(network); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// parseTaikoNetwork reads the network definition in the given file, and the
+// genesis allocation it refers to.
+func parseTaikoNetwork(fsys fs.FS, file string) (*TaikoNetwork, error) {
+	blob, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	// Start from a fresh copy of the default Taiko chain config, the definition
+	// overrides the fields it specifies.
+	network := &TaikoNetwork{
+		GasLimit: defaultTaikoGasLimit,
+		BaseFee:  big.NewInt(defaultTaikoBaseFee),
+	}
+	if network.Config, err = copyChainConfig(params.TaikoChainConfig); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(blob, network); err != nil {
+		return nil, err
+	}
+
+	if network.AllocFile != "" {
+		if network.Alloc != nil {
+			return nil, errors.New("both alloc and allocFile are specified")
+		}
+		allocPath := path.Join(path.Dir(file), network.AllocFile)
+		allocJSON, err := fs.ReadFile(fsys, allocPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := network.Alloc.UnmarshalJSON(allocJSON); err != nil {
+			return nil, fmt.Errorf("invalid genesis alloc %s: %w", allocPath, err)
+		}
+		network.AllocFile = ""
+	}
+	// The TaikoL2 contract is the one predeployed by the genesis allocation.
+	genesis := network.Genesis()
+	genesis.deriveTaikoL2Contract()
+	network.Config = genesis.Config
+	return network, nil
+}
+
+// Register adds the given network definition to the registry.
+func (r *TaikoNetworkRegistry) Register(network *TaikoNetwork) error {
+	if network.Name == "" {
+		return errors.New("missing taiko network name")
+	}
+	if network.Config == nil || network.Config.ChainID == nil {
+		return fmt.Errorf("missing chain ID of taiko network %s", network.Name)
+	}
+	if !network.Config.Taiko {
+		return fmt.Errorf("taiko network %s is not a taiko chain", network.Name)
+	}
+	if err := network.Config.CheckConfigForkOrder(); err != nil {
+		return fmt.Errorf("invalid chain config of taiko network %s: %w", network.Name, err)
+	}
+	network, err := network.copy()
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.networks[network.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrTaikoNetworkExists, network.Name)
+	}
+	chainID := network.Config.ChainID.Uint64()
+	if name, ok := r.ids[chainID]; ok {
+		return fmt.Errorf("%w: chain ID %d used by %s", ErrTaikoNetworkExists, chainID, name)
+	}
+	r.networks[network.Name] = network
+	r.ids[chainID] = network.Name
+
+	return nil
+}
+
+// Network returns a copy of the network definition with the given name.
+func (r *TaikoNetworkRegistry) Network(name string) (*TaikoNetwork, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	network, ok := r.networks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTaikoNetworkNotFound, name)
+	}
+	return network.copy()
+}
+
+// NetworkByID returns a copy of the network definition with the given chain ID.
+func (r *TaikoNetworkRegistry) NetworkByID(chainID uint64) (*TaikoNetwork, error) {
+	r.lock.RLock()
+	name, ok := r.ids[chainID]
+	r.lock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: chain ID %d", ErrTaikoNetworkNotFound, chainID)
+	}
+	return r.Network(name)
+}
+
+// Resolve returns a copy of the network definition with the given name. If no
+// name is given, the network with the given chain ID is returned instead, or the
+// default network if the chain ID is unknown.
+func (r *TaikoNetworkRegistry) Resolve(name string, chainID uint64) (*TaikoNetwork, error) {
+	if name != "" {
+		return r.Network(name)
+	}
+	network, err := r.NetworkByID(chainID)
+	if errors.Is(err, ErrTaikoNetworkNotFound) {
+		return r.Network(DefaultTaikoNetwork)
+	}
+	return network, err
+}
+
+// Names returns the sorted names of all registered networks.
+func (r *TaikoNetworkRegistry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	names := make([]string, 0, len(r.networks))
+	for name := range r.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// copyChainConfig returns a deep copy of the given chain config.
+func copyChainConfig(config *params.ChainConfig) (*params.ChainConfig, error) {
+	blob, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	cpy := new(params.ChainConfig)
+	if err := json.Unmarshal(blob, cpy); err != nil {
+		return nil, err
+	}
+	return cpy, nil
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaikoNetworkRegistryBuiltins(t *testing.T) {
+	registry, err := NewTaikoNetworkRegistry()
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"askja", "eldfell", "grimsvotn", "internal-1", "internal-2", "mainnet", "snaefellsjokull",
+	}, registry.Names())
+
+	mainnet, err := registry.Network("mainnet")
+	require.Nil(t, err)
+	require.Equal(t, params.TaikoMainnetNetworkID, mainnet.Config.ChainID)
+	require.Equal(t, params.TaikoL2LegacyAddress, mainnet.Config.TaikoParams().L2Contract)
+	require.Equal(t, uint64(6000000), mainnet.GasLimit)
+	require.NotEmpty(t, mainnet.Alloc)
+
+	eldfell, err := registry.NetworkByID(params.EldfellNetworkID.Uint64())
+	require.Nil(t, err)
+	require.Equal(t, "eldfell", eldfell.Name)
+	require.Equal(t, params.TaikoL2Address, eldfell.Config.TaikoParams().L2Contract)
+
+	// The networks predating the TaikoL2 proxy use the legacy TaikoL2 contract.
+	askja, err := registry.Network("askja")
+	require.Nil(t, err)
+	require.Equal(t, params.TaikoL2LegacyAddress, askja.Config.TaikoParams().L2Contract)
+
+	// The TaikoL2 contracts predeployed by the built-in networks don't implement
+	// anchorV2, so none of them schedules the Taiko hard forks.
+	for _, name := range registry.Names() {
+		network, err := registry.Network(name)
+		require.Nil(t, err)
+		require.Nil(t, network.Config.OntakeBlock, name)
+	}
+
+	// Unknown chain IDs fall back to the default network.
+	network, err := registry.Resolve("", 1)
+	require.Nil(t, err)
+	require.Equal(t, DefaultTaikoNetwork, network.Name)
+
+	_, err = registry.Resolve("unknown", params.EldfellNetworkID.Uint64())
+	require.ErrorIs(t, err, ErrTaikoNetworkNotFound)
+}
+
+func TestTaikoGenesisL2Contract(t *testing.T) {
+	code := []byte{0x60, 0x00}
+	newGenesis := func(alloc GenesisAlloc) *Genesis {
+		config := *params.TaikoChainConfig
+		return &Genesis{Config: &config, Alloc: alloc, BaseFee: big.NewInt(params.InitialBaseFee)}
+	}
+
+	// The contract predeployed by the allocation replaces the configured one.
+	genesis := newGenesis(GenesisAlloc{params.TaikoL2LegacyAddress: {Code: code, Balance: common.Big0}})
+	db := rawdb.NewMemoryDatabase()
+	config, _, err := SetupGenesisBlock(db, trie.NewDatabase(db), genesis)
+	require.Nil(t, err)
+	require.Equal(t, params.TaikoL2LegacyAddress, config.TaikoParams().L2Contract)
+	require.Equal(t, params.TaikoL2Address, params.TaikoChainConfig.TaikoParams().L2Contract)
+
+	// The configured contract is kept if it's predeployed.
+	genesis = newGenesis(GenesisAlloc{
+		params.TaikoL2Address:       {Code: code, Balance: common.Big0},
+		params.TaikoL2LegacyAddress: {Code: code, Balance: common.Big0},
+	})
+	genesis.Config.TaikoConfig = &params.TaikoConfig{L2Contract: params.TaikoL2LegacyAddress}
+	genesis.deriveTaikoL2Contract()
+	require.Equal(t, params.TaikoL2LegacyAddress, genesis.Config.TaikoParams().L2Contract)
+
+	// Non Taiko chains are left untouched.
+	genesis = &Genesis{
+		Config: params.AllEthashProtocolChanges,
+		Alloc:  GenesisAlloc{params.TaikoL2LegacyAddress: {Code: code, Balance: common.Big0}},
+	}
+	genesis.deriveTaikoL2Contract()
+	require.Nil(t, genesis.Config.TaikoConfig)
+}
+
+func TestTaikoNetworkRegistryCopies(t *testing.T) {
+	registry, err := NewTaikoNetworkRegistry()
+	require.Nil(t, err)
+
+	first, err := registry.Network("mainnet")
+	require.Nil(t, err)
+	first.Config.ChainID = big.NewInt(1)
+	first.Config.TaikoConfig.AnchorGasLimit = 1
+	first.Alloc[common.Address{0xaa}] = GenesisAccount{Balance: common.Big1}
+
+	second, err := registry.Network("mainnet")
+	require.Nil(t, err)
+	require.Equal(t, params.TaikoMainnetNetworkID, second.Config.ChainID)
+	require.Equal(t, params.AnchorGasLimit, second.Config.TaikoConfig.AnchorGasLimit)
+	require.NotContains(t, second.Alloc, common.Address{0xaa})
+
+	// Two networks can be built in the same process.
+	askja := TaikoGenesisBlock(params.AskjaNetworkID.Uint64())
+	grimsvotn := TaikoGenesisBlock(params.GrimsvotnNetworkID.Uint64())
+	require.Equal(t, params.AskjaNetworkID, askja.Config.ChainID)
+	require.Equal(t, params.GrimsvotnNetworkID, grimsvotn.Config.ChainID)
+	require.NotEqual(t, askja.Config.TaikoParams().L2Contract, grimsvotn.Config.TaikoParams().L2Contract)
+
+	// The shared default config is left untouched.
+	require.Equal(t, params.TaikoMainnetNetworkID, params.TaikoChainConfig.ChainID)
+	require.Equal(t, params.TaikoL2Address, params.TaikoChainConfig.TaikoParams().L2Contract)
+}
+
+func TestTaikoNetworkRegistryLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.Mkdir(filepath.Join(dir, "allocs"), 0o755))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "allocs", "devnet.json"), []byte(`{
+		"0x0000000000000000000000000000000000000001": {"balance": "0x1"}
+	}`), 0o644))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "devnet.json"), []byte(`{
+		"name": "devnet",
+		"config": {
+			"chainId": 1337,
+			"ontakeBlock": 0,
+			"taikoConfig": {"anchorGasLimit": 180000}
+		},
+		"allocFile": "allocs/devnet.json",
+		"baseFee": 1,
+		"bootnodes": ["enode://abc@127.0.0.1:30303"]
+	}`), 0o644))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "inline.json"), []byte(`{
+		"name": "inline",
+		"config": {"chainId": 1338},
+		"alloc": {"0x0000000000000000000000000000000000000002": {"balance": "0x2"}},
+		"gasLimit": 30000000
+	}`), 0o644))
+
+	registry, err := NewTaikoNetworkRegistry()
+	require.Nil(t, err)
+	require.Nil(t, registry.LoadDir(dir))
+
+	devnet, err := registry.Resolve("devnet", 0)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(1337), devnet.Config.ChainID)
+	require.True(t, devnet.Config.IsOntake(common.Big0))
+	require.Equal(t, uint64(180000), devnet.Config.TaikoParams().AnchorGasLimit)
+	require.Equal(t, params.GoldenTouchAccount, devnet.Config.TaikoParams().AnchorSender)
+	require.Equal(t, uint64(6000000), devnet.GasLimit)
+	require.Equal(t, common.Big1, devnet.BaseFee)
+	require.Equal(t, []string{"enode://abc@127.0.0.1:30303"}, devnet.Bootnodes)
+	require.Equal(t, common.Big1, devnet.Alloc[common.HexToAddress("0x01")].Balance)
+
+	inline, err := registry.NetworkByID(1338)
+	require.Nil(t, err)
+	require.Equal(t, uint64(30000000), inline.Genesis().GasLimit)
+	require.Equal(t, common.Big2, inline.Alloc[common.HexToAddress("0x02")].Balance)
+
+	// Networks can't be registered twice.
+	require.ErrorIs(t, registry.LoadDir(dir), ErrTaikoNetworkExists)
+}
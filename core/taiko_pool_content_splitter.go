@@ -1,16 +1,87 @@
 package core
 
 import (
+	"bytes"
+	"compress/zlib"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+// Supported transactions list compression modes, the proposer posts the compressed
+// RLP encoded bytes of a transactions list to L1.
+const (
+	TxListCompressionNone = "none"
+	TxListCompressionZlib = "zlib"
+)
+
+// compressTxList compresses the given RLP encoded transactions list bytes using
+// the given compression mode.
+func compressTxList(compression string, b []byte) ([]byte, error) {
+	switch compression {
+	case "", TxListCompressionNone:
+		return b, nil
+	case TxListCompressionZlib:
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		if _, err := w.Write(b); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported txList compression: %s", compression)
+	}
+}
+
+//go:generate go run github.com/fjl/gencodec -type PreBuiltTxList -field-override preBuiltTxListMarshaling -out gen_taiko_pre_built_tx_list.go
+
+// PreBuiltTxList is a transactions list splitted from the pool content, along with
+// its size information. GasUsed and Tips are only set when the transactions list
+// has been execution-validated by a TxSimulator, Lanes is only set when priority
+// sender lanes are configured.
+type PreBuiltTxList struct {
+	TxList                types.Transactions `json:"txList" gencodec:"required"`
+	BytesLength           uint64             `json:"bytesLength" gencodec:"required"`
+	CompressedBytesLength uint64             `json:"compressedBytesLength" gencodec:"required"`
+	GasUsed               uint64             `json:"gasUsed"`
+	Tips                  *big.Int           `json:"tips"`
+	Lanes                 []*TxLaneUsage     `json:"lanes,omitempty"`
+
+	txsGasUsed []uint64   // Gas used by each simulated transaction
+	txsTips    []*big.Int // Tips paid by each simulated transaction
+	txsLane    []string   // Priority sender lane of each transaction, if lanes are configured
+}
+
+type preBuiltTxListMarshaling struct {
+	BytesLength           hexutil.Uint64
+	CompressedBytesLength hexutil.Uint64
+	GasUsed               hexutil.Uint64
+	Tips                  *hexutil.Big
+}
+
+// TxSimulator executes transactions on top of the current chain head state. It's
+// used by PoolContentSplitter to drop the transactions which would be skipped when
+// sealing a block, and to pack the transactions lists on their actual gas used.
+type TxSimulator interface {
+	// ResetGasPool starts a new transactions list with the given gas limit, the
+	// state changes of the previous lists are kept.
+	ResetGasPool(gasLimit uint64)
+
+	// Apply executes the given transaction, its state changes are only kept on
+	// success. It returns the gas used and the tips paid by the transaction.
+	Apply(tx *types.Transaction) (uint64, *big.Int, error)
+}
+
 // PoolContent represents a response body of a `txpool_content` RPC call.
 type PoolContent map[common.Address]types.Transactions
 
@@ -64,9 +135,14 @@ type PoolContentSplitter struct {
 	maxBytesPerTxList       uint64
 	minTxGasLimit           uint64
 	locals                  []common.Address
+	compression             string
+	simulator               TxSimulator
+	lanes                   *TxLanesConfig
 }
 
-// NewPoolContentSplitter creates a new PoolContentSplitter instance.
+// NewPoolContentSplitter creates a new PoolContentSplitter instance. The lanes
+// configuration is optional, without it the locals' transactions are split into
+// their own transactions lists, ahead of the other ones.
 func NewPoolContentSplitter(
 	chainID *big.Int,
 	maxTransactionsPerBlock uint64,
@@ -74,6 +150,9 @@ func NewPoolContentSplitter(
 	maxBytesPerTxList uint64,
 	minTxGasLimit uint64,
 	locals []string,
+	compression string,
+	simulator TxSimulator,
+	lanes *TxLanesConfig,
 ) (*PoolContentSplitter, error) {
 	var localsAddresses []common.Address
 	for _, account := range locals {
@@ -84,6 +163,15 @@ func NewPoolContentSplitter(
 		}
 	}
 
+	if _, err := compressTxList(compression, nil); err != nil {
+		return nil, err
+	}
+	if lanes != nil {
+		if err := lanes.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &PoolContentSplitter{
 		chainID:                 chainID,
 		maxTransactionsPerBlock: maxTransactionsPerBlock,
@@ -91,104 +179,291 @@ func NewPoolContentSplitter(
 		maxBytesPerTxList:       maxBytesPerTxList,
 		minTxGasLimit:           minTxGasLimit,
 		locals:                  localsAddresses,
+		compression:             compression,
+		simulator:               simulator,
+		lanes:                   lanes,
 	}, nil
 }
 
 // Split splits the given transaction pool content to make each splitted
 // transactions list satisfies the rules defined in Taiko protocol.
-func (p *PoolContentSplitter) Split(poolContent PoolContent) []types.Transactions {
+func (p *PoolContentSplitter) Split(poolContent PoolContent) []*PreBuiltTxList {
+	// With priority sender lanes, all the transactions are scheduled into the
+	// same transactions lists, following the lanes' quotas and policy.
+	if p.lanes != nil {
+		return p.splitTxs(newTxLaneScheduler(p.chainID, poolContent, p.lanes, p.locals))
+	}
+
 	var (
 		localTxs, remoteTxs   = poolContent.ToTxsByPriceAndNonce(p.chainID, p.locals)
-		splittedLocalTxLists  = p.splitTxs(localTxs)
-		splittedRemoteTxLists = p.splitTxs(remoteTxs)
+		splittedLocalTxLists  = p.splitTxs(newSingleTxLaneScheduler(localTxs))
+		splittedRemoteTxLists = p.splitTxs(newSingleTxLaneScheduler(remoteTxs))
 	)
 
-	splittedTxLists := append(splittedLocalTxLists, splittedRemoteTxLists...)
+	return append(splittedLocalTxLists, splittedRemoteTxLists...)
+}
 
-	return splittedTxLists
+// NewPreBuiltTxList creates a new PreBuiltTxList with the size information of
+// the given transactions list, using the splitter's compression mode.
+func (p *PoolContentSplitter) NewPreBuiltTxList(txs types.Transactions) *PreBuiltTxList {
+	// Transactions list's RLP encoding and compressing errors have already been
+	// checked in `validateTx`, so no need to check the errors here.
+	b, _ := rlp.EncodeToBytes(txs)
+	compressed, _ := compressTxList(p.compression, b)
+
+	return &PreBuiltTxList{
+		TxList:                txs,
+		BytesLength:           uint64(len(b)),
+		CompressedBytesLength: uint64(len(compressed)),
+	}
+}
+
+// Truncate returns a new PreBuiltTxList containing only the first n transactions
+// of the given transactions list.
+func (p *PoolContentSplitter) Truncate(txList *PreBuiltTxList, n int) *PreBuiltTxList {
+	if n >= len(txList.TxList) {
+		return txList
+	}
+	truncated := p.NewPreBuiltTxList(txList.TxList[:n])
+	if txList.txsLane != nil {
+		truncated.txsLane = txList.txsLane[:n]
+		truncated.Lanes = txLaneUsages(truncated.TxList, truncated.txsLane)
+	}
+	if txList.Tips != nil {
+		truncated.txsGasUsed, truncated.txsTips = txList.txsGasUsed[:n], txList.txsTips[:n]
+		truncated.Tips = new(big.Int)
+		for i := 0; i < n; i++ {
+			truncated.GasUsed += truncated.txsGasUsed[i]
+			truncated.Tips.Add(truncated.Tips, truncated.txsTips[i])
+		}
+	}
+
+	return truncated
+}
+
+// TxListSize returns the size of the given transactions list's RLP encoded bytes,
+// compressed using the given compression mode.
+func TxListSize(compression string, txs []*types.Transaction) (int, error) {
+	b, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		return 0, err
+	}
+
+	compressed, err := compressTxList(compression, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(compressed), nil
+}
+
+// TxListFits reports whether the given transactions list's RLP encoded bytes,
+// compressed using the given compression mode, fit in the given size limit, along
+// with their size. The list is only compressed when its RLP encoded size could be
+// over the limit, otherwise the returned size is the RLP encoded one.
+func TxListFits(compression string, txs []*types.Transaction, limit uint64) (int, bool, error) {
+	b, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		return 0, false, err
+	}
+	size := uint64(len(b))
+	if size+maxCompressionOverhead(compression, size) <= limit {
+		return len(b), true, nil
+	}
+
+	compressed, err := compressTxList(compression, b)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return len(compressed), uint64(len(compressed)) <= limit, nil
+}
+
+// maxCompressionOverhead returns the maximum number of bytes the given compression
+// mode adds to data of the given size. Zlib adds its header and checksum, deflate
+// falls back to stored blocks for incompressible data, with a header for each
+// block, flushed at the latest every 16KiB, and for the final empty block.
+func maxCompressionOverhead(compression string, size uint64) uint64 {
+	if compression == TxListCompressionZlib {
+		return 6 + 5*(size/16384+2)
+	}
+	return 0
+}
+
+// joinTxList returns the RLP encoding of the transactions list whose items are
+// the given concatenated RLP encoded transactions.
+func joinTxList(items ...[]byte) []byte {
+	w := rlp.NewEncoderBuffer(nil)
+	l := w.List()
+	for _, item := range items {
+		w.Write(item)
+	}
+	w.ListEnd(l)
+	return w.ToBytes()
 }
 
-// validateTx checks whether the given transaction is valid according
-// to the rules in Taiko protocol.
-func (p *PoolContentSplitter) validateTx(tx *types.Transaction) error {
+// validateTx checks whether the given transaction is valid according to the rules
+// in Taiko protocol, and returns its RLP encoding.
+func (p *PoolContentSplitter) validateTx(tx *types.Transaction) ([]byte, error) {
 	if tx.Gas() < p.minTxGasLimit || tx.Gas() > p.blockMaxGasLimit {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"transaction %s gas limit reaches the limits, got=%v, lowerBound=%v, upperBound=%v",
 			tx.Hash(), tx.Gas(), p.minTxGasLimit, p.blockMaxGasLimit,
 		)
 	}
 
-	b, err := rlp.EncodeToBytes(tx)
+	enc, err := rlp.EncodeToBytes(tx)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to rlp encode the pending transaction %s: %w", tx.Hash(), err,
+		return nil, fmt.Errorf(
+			"failed to encode the pending transaction %s: %w", tx.Hash(), err,
 		)
 	}
 
-	if len(b) > int(p.maxBytesPerTxList) {
-		return fmt.Errorf(
-			"size of transaction %s's rlp encoded bytes is bigger than the limit, got=%v, limit=%v",
-			tx.Hash(), len(b), p.maxBytesPerTxList,
+	compressed, err := compressTxList(p.compression, joinTxList(enc))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to compress the pending transaction %s: %w", tx.Hash(), err,
 		)
 	}
 
-	return nil
+	if len(compressed) > int(p.maxBytesPerTxList) {
+		return nil, fmt.Errorf(
+			"size of transaction %s's encoded bytes is bigger than the limit, got=%v, limit=%v",
+			tx.Hash(), len(compressed), p.maxBytesPerTxList,
+		)
+	}
+
+	return enc, nil
 }
 
 // isTxBufferFull checks whether the given transaction can be appended to the
-// current transaction list
+// current transaction list, given the concatenated RLP encoded transactions of
+// the list and the RLP encoding of the transaction.
 // NOTE: this function *MUST* be called after using `validateTx` to check every
 // inside transaction is valid.
-func (p *PoolContentSplitter) isTxBufferFull(t *types.Transaction, txs []*types.Transaction, gas uint64) bool {
+func (p *PoolContentSplitter) isTxBufferFull(t *types.Transaction, txs []*types.Transaction, gas uint64, encoded []byte, enc []byte) bool {
 	if len(txs) >= int(p.maxTransactionsPerBlock) {
 		return true
 	}
 
-	if gas+t.Gas() > p.blockMaxGasLimit {
+	// When simulating, the transactions list is packed on the actual gas used,
+	// which is checked by the simulator's gas pool instead.
+	if p.simulator == nil && gas+t.Gas() > p.blockMaxGasLimit {
 		return true
 	}
 
-	// Transactions list's RLP encoding error has already been checked in
-	// `validateTx`, so no need to check the error here.
-	if b, _ := rlp.EncodeToBytes(append([]*types.Transaction{t}, txs...)); len(b) > int(p.maxBytesPerTxList) {
+	// The transactions list is only compressed once its RLP encoded size nears
+	// the limit, compressing it for every transaction would be quadratic.
+	size := rlp.ListSize(uint64(len(encoded) + len(enc)))
+	if size+maxCompressionOverhead(p.compression, size) <= p.maxBytesPerTxList {
+		return false
+	}
+	if p.compression == "" || p.compression == TxListCompressionNone {
 		return true
 	}
 
-	return false
+	// Transactions list's compressing errors have already been checked in
+	// `validateTx`, so no need to check the errors here.
+	compressed, _ := compressTxList(p.compression, joinTxList(encoded, enc))
+	return len(compressed) > int(p.maxBytesPerTxList)
 }
 
 // splitTxs the internal implementation Split, splits the given transactions into small transactions lists
 // which satisfy the protocol constraints.
-func (p *PoolContentSplitter) splitTxs(txs *types.TransactionsByPriceAndNonce) []types.Transactions {
+func (p *PoolContentSplitter) splitTxs(txs *txLaneScheduler) []*PreBuiltTxList {
 	var (
-		splittedTxLists        = make([]types.Transactions, 0)
+		splittedTxLists        = make([]*PreBuiltTxList, 0)
 		txBuffer               = make([]*types.Transaction, 0, p.maxTransactionsPerBlock)
 		gasBuffer       uint64 = 0
+		encodedBuffer          = make([]byte, 0)
+		txsGasUsed             = make([]uint64, 0, p.maxTransactionsPerBlock)
+		txsTips                = make([]*big.Int, 0, p.maxTransactionsPerBlock)
+		txsLane                = make([]string, 0, p.maxTransactionsPerBlock)
 	)
+	// flush makes all transactions in current buffer a new splitted transaction
+	// list, and then resets the buffer.
+	flush := func() {
+		txList := p.NewPreBuiltTxList(txBuffer)
+		if p.simulator != nil {
+			txList.txsGasUsed, txList.txsTips = txsGasUsed, txsTips
+			txList.Tips = new(big.Int)
+			for i := range txBuffer {
+				txList.GasUsed += txsGasUsed[i]
+				txList.Tips.Add(txList.Tips, txsTips[i])
+			}
+			p.simulator.ResetGasPool(p.blockMaxGasLimit)
+		}
+		if p.lanes != nil {
+			txList.txsLane = txsLane
+			txList.Lanes = txLaneUsages(txBuffer, txsLane)
+		}
+		splittedTxLists = append(splittedTxLists, txList)
+
+		txBuffer = make([]*types.Transaction, 0, p.maxTransactionsPerBlock)
+		txsGasUsed = make([]uint64, 0, p.maxTransactionsPerBlock)
+		txsTips = make([]*big.Int, 0, p.maxTransactionsPerBlock)
+		txsLane = make([]string, 0, p.maxTransactionsPerBlock)
+		gasBuffer = 0
+		encodedBuffer = make([]byte, 0)
+		txs.NewList()
+	}
+	if p.simulator != nil {
+		p.simulator.ResetGasPool(p.blockMaxGasLimit)
+	}
 	for {
 		tx := txs.Peek()
 		if tx == nil {
+			// The remaining transactions may only be beyond their lanes' quotas
+			// of the current transactions list.
+			if len(txBuffer) > 0 && txs.Pending() {
+				flush()
+				continue
+			}
 			break
 		}
 
 		// If the transaction is invalid, we simply ignore it.
-		if err := p.validateTx(tx); err != nil {
+		enc, err := p.validateTx(tx)
+		if err != nil {
 			log.Debug("Invalid pending transaction", "hash", tx.Hash(), "error", err)
 			txs.Pop() // If this tx is invalid, ignore this sender's other txs in pool.
 			continue
 		}
 
 		// If the transactions buffer is full, we make all transactions in
-		// current buffer a new splitted transaction list, and then reset the
-		// buffer.
-		if p.isTxBufferFull(tx, txBuffer, gasBuffer) {
-			splittedTxLists = append(splittedTxLists, txBuffer)
-			txBuffer = make([]*types.Transaction, 0, p.maxTransactionsPerBlock)
-			gasBuffer = 0
+		// current buffer a new splitted transaction list.
+		if p.isTxBufferFull(tx, txBuffer, gasBuffer, encodedBuffer, enc) {
+			flushed := len(txBuffer) > 0
+			flush()
+
+			// The lanes' quotas are reset for the new transactions list, which
+			// may change the lane the next transaction is taken from.
+			if p.lanes != nil && flushed {
+				continue
+			}
+		}
+
+		// If a simulator is given, drop the transactions which would be skipped
+		// when sealing the block.
+		if p.simulator != nil {
+			txGasUsed, txTips, err := p.simulator.Apply(tx)
+			if errors.Is(err, ErrGasLimitReached) && len(txBuffer) > 0 {
+				flush()
+				continue
+			}
+			if err != nil {
+				log.Debug("Pending transaction failed simulation", "hash", tx.Hash(), "error", err)
+				txs.Pop() // If this tx is invalid, ignore this sender's other txs in pool.
+				continue
+			}
+			txsGasUsed = append(txsGasUsed, txGasUsed)
+			txsTips = append(txsTips, txTips)
 		}
 
 		txBuffer = append(txBuffer, tx)
+		txsLane = append(txsLane, txs.Lane())
 		gasBuffer += tx.Gas()
+		encodedBuffer = append(encodedBuffer, enc...)
 
 		txs.Shift()
 	}
@@ -196,7 +471,7 @@ func (p *PoolContentSplitter) splitTxs(txs *types.TransactionsByPriceAndNonce) [
 	// Maybe there are some remaining transactions in current buffer,
 	// make them a new transactions list too.
 	if len(txBuffer) > 0 {
-		splittedTxLists = append(splittedTxLists, txBuffer)
+		flush()
 	}
 
 	return splittedTxLists
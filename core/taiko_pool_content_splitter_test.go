@@ -91,6 +91,100 @@ func TestPoolContentSplit(t *testing.T) {
 	require.Equal(t, 2, len(splitted))
 }
 
+func TestPoolContentSplitCompression(t *testing.T) {
+	testKey, err := crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
+	require.Nil(t, err)
+
+	var (
+		signer = types.LatestSignerForChainID(new(big.Int).SetUint64(1336))
+		txs    types.Transactions
+	)
+	for nonce := uint64(0); nonce < 10; nonce++ {
+		txs = append(txs, types.MustSignNewTx(testKey, signer, &types.LegacyTx{
+			Gas:   21000,
+			Nonce: nonce,
+			Data:  make([]byte, 256),
+		}))
+	}
+	b, err := rlp.EncodeToBytes(txs[:1])
+	require.Nil(t, err)
+	maxBytesPerTxList := uint64(len(b) + len(b)/2)
+
+	_, err = NewPoolContentSplitter(new(big.Int).SetUint64(1336), 10, 21000*10, uint64(len(b)), 21000, nil, "unknown", nil, nil)
+	require.NotNil(t, err)
+
+	// Without compression, each list can only contain a single transaction.
+	splitter, err := NewPoolContentSplitter(new(big.Int).SetUint64(1336), 10, 21000*10, maxBytesPerTxList, 21000, nil, TxListCompressionNone, nil, nil)
+	require.Nil(t, err)
+
+	splitted := splitter.Split(PoolContent{crypto.PubkeyToAddress(testKey.PublicKey): txs})
+	require.Equal(t, 10, len(splitted))
+	for _, txList := range splitted {
+		require.Equal(t, txList.BytesLength, txList.CompressedBytesLength)
+		require.LessOrEqual(t, txList.BytesLength, maxBytesPerTxList)
+	}
+
+	// With zlib compression, the lists are packed against their compressed size.
+	splitter, err = NewPoolContentSplitter(new(big.Int).SetUint64(1336), 10, 21000*10, maxBytesPerTxList, 21000, nil, TxListCompressionZlib, nil, nil)
+	require.Nil(t, err)
+
+	splitted = splitter.Split(PoolContent{crypto.PubkeyToAddress(testKey.PublicKey): txs})
+	require.Less(t, len(splitted), 10)
+	for i, txList := range splitted {
+		require.Less(t, txList.CompressedBytesLength, txList.BytesLength)
+		require.LessOrEqual(t, txList.CompressedBytesLength, maxBytesPerTxList)
+
+		// The lists are full, even though they're not compressed for each transaction.
+		if i < len(splitted)-1 {
+			size, err := TxListSize(TxListCompressionZlib, append(txList.TxList, splitted[i+1].TxList[0]))
+			require.Nil(t, err)
+			require.Greater(t, uint64(size), maxBytesPerTxList)
+		}
+	}
+}
+
+func TestMaxCompressionOverhead(t *testing.T) {
+	require.Zero(t, maxCompressionOverhead(TxListCompressionNone, 1024))
+
+	// Random bytes are incompressible, zlib stores them in raw blocks.
+	for _, size := range []int{0, 1, 1024, 16384, 16385, 65535, 65536, 200000} {
+		b := randomBytes(size)
+		compressed, err := compressTxList(TxListCompressionZlib, b)
+		require.Nil(t, err)
+		require.LessOrEqual(t, uint64(len(compressed)), uint64(size)+maxCompressionOverhead(TxListCompressionZlib, uint64(size)), "size %d", size)
+	}
+}
+
+func TestTxListFits(t *testing.T) {
+	txs := []*types.Transaction{types.NewTx(&types.LegacyTx{Data: make([]byte, 1024)})}
+	raw, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+	compressed, err := TxListSize(TxListCompressionZlib, txs)
+	require.Nil(t, err)
+	require.Less(t, compressed, len(raw))
+
+	// Lists far under the limit are not compressed.
+	size, fits, err := TxListFits(TxListCompressionZlib, txs, uint64(2*len(raw)))
+	require.Nil(t, err)
+	require.True(t, fits)
+	require.Equal(t, len(raw), size)
+
+	// Lists over the limit in raw size may still fit once compressed.
+	size, fits, err = TxListFits(TxListCompressionZlib, txs, uint64(compressed))
+	require.Nil(t, err)
+	require.True(t, fits)
+	require.Equal(t, compressed, size)
+
+	size, fits, err = TxListFits(TxListCompressionZlib, txs, uint64(compressed-1))
+	require.Nil(t, err)
+	require.False(t, fits)
+	require.Equal(t, compressed, size)
+
+	_, fits, err = TxListFits(TxListCompressionNone, txs, uint64(len(raw)-1))
+	require.Nil(t, err)
+	require.False(t, fits)
+}
+
 // RandomBytes generates a random bytes.
 func randomBytes(size int) (b []byte) {
 	b = make([]byte, size)
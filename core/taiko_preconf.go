@@ -0,0 +1,118 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// headPreconfBlockGauge tracks the number of the newest preconfirmed block.
+var headPreconfBlockGauge = metrics.NewRegisteredGauge("chain/head/preconf", nil)
+
+// PreconfHeadEvent is posted when a new preconfirmed L2 block becomes the chain head.
+type PreconfHeadEvent struct{ Header *types.Header }
+
+// PreconfRollbackEvent is posted when preconfirmed L2 blocks are dropped from the
+// canonical chain, because the L1 derived chain diverged from them. The headers
+// are in ascending order.
+type PreconfRollbackEvent struct{ Headers []*types.Header }
+
+// CurrentPreconfBlock retrieves the newest preconfirmed L2 block, i.e. the newest
+// block built locally ahead of its inclusion on L1, nil is returned if all the
+// canonical blocks are derived from L1.
+func (bc *BlockChain) CurrentPreconfBlock() *types.Header {
+	return bc.currentPreconfBlock.Load()
+}
+
+// SetPreconfirmed sets the given canonical block as the newest preconfirmed block.
+func (bc *BlockChain) SetPreconfirmed(header *types.Header) {
+	bc.currentPreconfBlock.Store(header)
+	rawdb.WriteHeadPreconfBlockHash(bc.db, header.Hash())
+	headPreconfBlockGauge.Update(int64(header.Number.Uint64()))
+
+	bc.preconfHeadFeed.Send(PreconfHeadEvent{Header: header})
+}
+
+// ConfirmPreconfirmed marks the preconfirmed blocks up to the given L1 derived
+// block as confirmed. Once the L1 derived chain reaches the newest preconfirmed
+// block, there is no preconfirmed block left.
+func (bc *BlockChain) ConfirmPreconfirmed(header *types.Header) {
+	preconf := bc.CurrentPreconfBlock()
+	if preconf == nil || header.Number.Cmp(preconf.Number) < 0 {
+		return
+	}
+	if bc.GetCanonicalHash(header.Number.Uint64()) != header.Hash() {
+		return
+	}
+	log.Info("Preconfirmed blocks confirmed by L1", "number", preconf.Number, "hash", preconf.Hash())
+	bc.clearPreconfirmed()
+}
+
+// IsPreconfirmedAncestor checks whether the given block is a canonical ancestor of
+// the newest preconfirmed block, i.e. a block the L1 derived chain can move to
+// without dropping the preconfirmed blocks on top of it.
+func (bc *BlockChain) IsPreconfirmedAncestor(header *types.Header) bool {
+	preconf := bc.CurrentPreconfBlock()
+	if preconf == nil || header.Number.Cmp(preconf.Number) > 0 {
+		return false
+	}
+	return bc.GetCanonicalHash(header.Number.Uint64()) == header.Hash() &&
+		bc.GetCanonicalHash(preconf.Number.Uint64()) == preconf.Hash()
+}
+
+// SubscribePreconfHeadEvent registers a subscription of PreconfHeadEvent.
+func (bc *BlockChain) SubscribePreconfHeadEvent(ch chan<- PreconfHeadEvent) event.Subscription {
+	return bc.scope.Track(bc.preconfHeadFeed.Subscribe(ch))
+}
+
+// SubscribePreconfRollbackEvent registers a subscription of PreconfRollbackEvent.
+func (bc *BlockChain) SubscribePreconfRollbackEvent(ch chan<- PreconfRollbackEvent) event.Subscription {
+	return bc.scope.Track(bc.preconfRollbackFeed.Subscribe(ch))
+}
+
+// rollbackPreconfirmed drops the preconfirmed blocks which are no longer part of
+// the canonical chain after a reorg or a rewind. The L1Origins of the dropped
+// blocks are expected to be already removed by repairL1Origins.
+//
+// Note, this function assumes that the chain mutex is held.
+func (bc *BlockChain) rollbackPreconfirmed() {
+	preconf := bc.CurrentPreconfBlock()
+	if preconf == nil || bc.GetCanonicalHash(preconf.Number.Uint64()) == preconf.Hash() {
+		return
+	}
+	var dropped []*types.Header
+	for header := preconf; header != nil && bc.GetCanonicalHash(header.Number.Uint64()) != header.Hash(); {
+		dropped = append([]*types.Header{header}, dropped...)
+		header = bc.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	bc.clearPreconfirmed()
+
+	log.Warn("Rolled back preconfirmed blocks", "count", len(dropped), "from", dropped[0].Number, "to", preconf.Number)
+	bc.preconfRollbackFeed.Send(PreconfRollbackEvent{Headers: dropped})
+}
+
+// clearPreconfirmed forgets the newest preconfirmed block.
+func (bc *BlockChain) clearPreconfirmed() {
+	bc.currentPreconfBlock.Store(nil)
+	rawdb.DeleteHeadPreconfBlockHash(bc.db)
+	headPreconfBlockGauge.Update(0)
+}
+
+// loadPreconfHead restores the newest preconfirmed block, if it's still part of
+// the canonical chain.
+func (bc *BlockChain) loadPreconfHead() {
+	hash := rawdb.ReadHeadPreconfBlockHash(bc.db)
+	if hash == (common.Hash{}) {
+		return
+	}
+	header := bc.GetHeaderByHash(hash)
+	if header == nil || bc.GetCanonicalHash(header.Number.Uint64()) != hash {
+		bc.clearPreconfirmed()
+		return
+	}
+	bc.currentPreconfBlock.Store(header)
+	headPreconfBlockGauge.Update(int64(header.Number.Uint64()))
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreconfirmedHead(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{Config: &config, BaseFee: common.Big1}
+	)
+	config.Taiko = true
+
+	genDb, blocks, _ := GenerateChainWithGenesis(genesis, engine, 5, nil)
+	fork, _ := GenerateChain(&config, blocks[2], engine, genDb, 1, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{0x01})
+	})
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.Nil(t, err)
+
+	var (
+		headCh     = make(chan PreconfHeadEvent, 1)
+		rollbackCh = make(chan PreconfRollbackEvent, 1)
+	)
+	defer chain.SubscribePreconfHeadEvent(headCh).Unsubscribe()
+	defer chain.SubscribePreconfRollbackEvent(rollbackCh).Unsubscribe()
+
+	// Blocks #4 and #5 are preconfirmed.
+	require.Nil(t, chain.CurrentPreconfBlock())
+	chain.SetPreconfirmed(blocks[4].Header())
+	require.Equal(t, blocks[4].Hash(), chain.CurrentPreconfBlock().Hash())
+	require.Equal(t, blocks[4].Hash(), (<-headCh).Header.Hash())
+
+	require.True(t, chain.IsPreconfirmedAncestor(blocks[2].Header()))
+	require.True(t, chain.IsPreconfirmedAncestor(blocks[4].Header()))
+	require.False(t, chain.IsPreconfirmedAncestor(fork[0].Header()))
+
+	// The preconfirmed head is persisted.
+	restarted, err := NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	require.Equal(t, blocks[4].Hash(), restarted.CurrentPreconfBlock().Hash())
+	restarted.Stop()
+
+	// The L1 derived chain catches up with the preconfirmed blocks.
+	chain.ConfirmPreconfirmed(blocks[3].Header())
+	require.Equal(t, blocks[4].Hash(), chain.CurrentPreconfBlock().Hash())
+	chain.ConfirmPreconfirmed(blocks[4].Header())
+	require.Nil(t, chain.CurrentPreconfBlock())
+	require.Equal(t, common.Hash{}, rawdb.ReadHeadPreconfBlockHash(db))
+
+	// The L1 derived chain diverges from the preconfirmed blocks.
+	chain.SetPreconfirmed(blocks[4].Header())
+	<-headCh
+
+	err = chain.InsertBlockWithoutSetHead(fork[0])
+	require.Nil(t, err)
+	_, err = chain.SetCanonical(fork[0])
+	require.Nil(t, err)
+
+	select {
+	case ev := <-rollbackCh:
+		require.Len(t, ev.Headers, 2)
+		require.Equal(t, blocks[3].Hash(), ev.Headers[0].Hash())
+		require.Equal(t, blocks[4].Hash(), ev.Headers[1].Hash())
+	case <-time.After(time.Second):
+		t.Fatal("preconfirmed blocks not rolled back")
+	}
+	require.Nil(t, chain.CurrentPreconfBlock())
+	require.Equal(t, common.Hash{}, rawdb.ReadHeadPreconfBlockHash(db))
+
+	// Rewinding the chain below the preconfirmed head rolls it back too.
+	chain.SetPreconfirmed(fork[0].Header())
+	<-headCh
+
+	require.Nil(t, chain.SetHead(2))
+	select {
+	case ev := <-rollbackCh:
+		require.Equal(t, []*types.Header{fork[0].Header()}, ev.Headers)
+	case <-time.After(time.Second):
+		t.Fatal("preconfirmed blocks not rolled back")
+	}
+	require.Nil(t, chain.CurrentPreconfBlock())
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// newTaikoBaseFeeTestChain inserts a block with a single transfer into a Taiko
+// chain with the given settings, and returns the inserted block and the state
+// after it.
+func newTaikoBaseFeeTestChain(t *testing.T, taikoConfig *params.TaikoConfig, ontakeBlock *big.Int, extra []byte) (*types.Block, *state.StateDB) {
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.TestChainConfig
+	)
+	config.Taiko = true
+	config.TaikoConfig = taikoConfig
+	config.OntakeBlock = ontakeBlock
+
+	genesis := &Genesis{
+		Config:  &config,
+		Alloc:   GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	_, blocks, _ := GenerateChainWithGenesis(genesis, ethash.NewFaker(), 1, func(i int, b *BlockGen) {
+		b.SetExtra(extra)
+		b.AddTx(types.MustSignNewTx(key, types.LatestSigner(&config), &types.LegacyTx{
+			To:       &common.Address{0xaa},
+			Gas:      params.TxGas,
+			GasPrice: new(big.Int).Mul(b.BaseFee(), common.Big2),
+		}))
+	})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	t.Cleanup(chain.Stop)
+
+	_, err = chain.InsertChain(blocks)
+	require.Nil(t, err)
+
+	state, err := chain.State()
+	require.Nil(t, err)
+
+	return blocks[0], state
+}
+
+func TestTaikoBaseFeeDestination(t *testing.T) {
+	var (
+		treasury    = common.HexToAddress("0x01")
+		destination = common.HexToAddress("0x02")
+	)
+	block, state := newTaikoBaseFeeTestChain(t, &params.TaikoConfig{
+		AnchorSender:       params.GoldenTouchAccount,
+		L2Contract:         params.TaikoL2Address,
+		Treasury:           treasury,
+		AnchorGasLimit:     params.AnchorGasLimit,
+		BaseFeeDestination: &destination,
+	}, nil, nil)
+
+	baseFee := new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))
+	require.Equal(t, baseFee, state.GetBalance(destination))
+	require.Zero(t, state.GetBalance(treasury).Sign())
+}
+
+func TestTaikoOntakeBaseFeeSharing(t *testing.T) {
+	treasury := common.HexToAddress("0x01")
+	block, state := newTaikoBaseFeeTestChain(t, &params.TaikoConfig{
+		AnchorSender:   params.GoldenTouchAccount,
+		L2Contract:     params.TaikoL2Address,
+		Treasury:       treasury,
+		AnchorGasLimit: params.AnchorGasLimit,
+	}, common.Big1, taiko.EncodeOntakeExtraData(25))
+
+	// 25% of the basefee goes to the block proposer, the rest to the treasury.
+	baseFee := new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))
+	shared := new(big.Int).Div(baseFee, big.NewInt(4))
+	require.Equal(t, new(big.Int).Sub(baseFee, shared), state.GetBalance(treasury))
+
+	// Before Ontake, the extra data isn't decoded, the treasury gets all the basefee.
+	block, state = newTaikoBaseFeeTestChain(t, &params.TaikoConfig{
+		AnchorSender:   params.GoldenTouchAccount,
+		L2Contract:     params.TaikoL2Address,
+		Treasury:       treasury,
+		AnchorGasLimit: params.AnchorGasLimit,
+	}, nil, taiko.EncodeOntakeExtraData(25))
+
+	baseFee = new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))
+	require.Equal(t, baseFee, state.GetBalance(treasury))
+}
@@ -0,0 +1,339 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Fairness policies of the priority sender lanes, deciding which lane the next
+// transaction of a transactions list is taken from.
+const (
+	// TxLanePolicyPriority serves the lanes in order, each one up to its quotas,
+	// before the transactions of the other senders.
+	TxLanePolicyPriority = "priority"
+
+	// TxLanePolicyRoundRobin makes the lanes and the other senders take turns,
+	// one transaction each, so that no lane can starve the others.
+	TxLanePolicyRoundRobin = "roundrobin"
+)
+
+// localsTxLane is the name of the implicit lane of the senders given to a single
+// `taiko_txPoolContent` call, when priority sender lanes are configured.
+const localsTxLane = "locals"
+
+// TxLane is a named group of priority senders, whose transactions are put in the
+// transactions lists ahead of the other senders' ones, within per list quotas.
+type TxLane struct {
+	Name          string           `json:"name"`
+	Senders       []common.Address `json:"senders"`
+	MaxTxsPerList uint64           `json:"maxTxsPerList,omitempty"` // Zero means no quota
+	MaxGasPerList uint64           `json:"maxGasPerList,omitempty"` // Zero means no quota
+}
+
+// TxLanesConfig is the configuration of the priority sender lanes used when
+// splitting the transaction pool content.
+type TxLanesConfig struct {
+	Lanes  []*TxLane `json:"lanes"`
+	Policy string    `json:"policy,omitempty"` // Defaults to TxLanePolicyPriority
+}
+
+// Validate checks whether the lanes configuration is well formed: the lanes have
+// unique names, each sender belongs to a single lane, and the policy is known.
+func (c *TxLanesConfig) Validate() error {
+	switch c.Policy {
+	case "", TxLanePolicyPriority, TxLanePolicyRoundRobin:
+	default:
+		return fmt.Errorf("unsupported txLane policy: %s", c.Policy)
+	}
+	var (
+		names   = make(map[string]bool)
+		senders = make(map[common.Address]string)
+	)
+	for _, lane := range c.Lanes {
+		if lane == nil || lane.Name == "" {
+			return errors.New("txLane without name")
+		}
+		if lane.Name == localsTxLane {
+			return fmt.Errorf("txLane name %q is reserved", lane.Name)
+		}
+		if names[lane.Name] {
+			return fmt.Errorf("duplicate txLane %q", lane.Name)
+		}
+		names[lane.Name] = true
+
+		for _, sender := range lane.Senders {
+			if other, ok := senders[sender]; ok {
+				return fmt.Errorf("sender %s in both txLanes %q and %q", sender, other, lane.Name)
+			}
+			senders[sender] = lane.Name
+		}
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the lanes configuration.
+func (c *TxLanesConfig) Copy() *TxLanesConfig {
+	cpy := &TxLanesConfig{Policy: c.Policy, Lanes: make([]*TxLane, len(c.Lanes))}
+	for i, lane := range c.Lanes {
+		laneCpy := *lane
+		laneCpy.Senders = append([]common.Address(nil), lane.Senders...)
+		cpy.Lanes[i] = &laneCpy
+	}
+	return cpy
+}
+
+// LoadTxLanesConfig reads a JSON encoded lanes configuration from the given file.
+func LoadTxLanesConfig(path string) (*TxLanesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := new(TxLanesConfig)
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid txLanes file %s: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid txLanes file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// TxLanes holds the priority sender lanes configuration of a node, which can be
+// replaced at runtime.
+type TxLanes struct {
+	config *TxLanesConfig
+	lock   sync.RWMutex
+}
+
+// NewTxLanes creates a TxLanes holding the given configuration, which may be nil
+// if no lanes are configured.
+func NewTxLanes(config *TxLanesConfig) (*TxLanes, error) {
+	lanes := new(TxLanes)
+	if err := lanes.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return lanes, nil
+}
+
+// Config returns a copy of the current lanes configuration, or nil if no lanes
+// are configured.
+func (l *TxLanes) Config() *TxLanesConfig {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if l.config == nil {
+		return nil
+	}
+	return l.config.Copy()
+}
+
+// SetConfig replaces the lanes configuration, a nil configuration removes all
+// the lanes.
+func (l *TxLanes) SetConfig(config *TxLanesConfig) error {
+	if config != nil {
+		if err := config.Validate(); err != nil {
+			return err
+		}
+		config = config.Copy()
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.config = config
+	return nil
+}
+
+// TxLaneUsage is the number of transactions and the gas limit taken by a priority
+// sender lane in a transactions list.
+type TxLaneUsage struct {
+	Lane         string         `json:"lane"`
+	Transactions hexutil.Uint64 `json:"transactions"`
+	Gas          hexutil.Uint64 `json:"gas"`
+}
+
+// txLaneUsages returns the usage of the priority sender lanes in the given
+// transactions list, given the lane of each transaction, the transactions of
+// the other senders having no lane.
+func txLaneUsages(txs types.Transactions, txsLane []string) []*TxLaneUsage {
+	var (
+		usages []*TxLaneUsage
+		index  = make(map[string]*TxLaneUsage)
+	)
+	for i, lane := range txsLane {
+		if lane == "" {
+			continue
+		}
+		usage, ok := index[lane]
+		if !ok {
+			usage = &TxLaneUsage{Lane: lane}
+			index[lane] = usage
+			usages = append(usages, usage)
+		}
+		usage.Transactions++
+		usage.Gas += hexutil.Uint64(txs[i].Gas())
+	}
+	return usages
+}
+
+// txLane is a lane of a txLaneScheduler, along with its usage in the
+// transactions list being built.
+type txLane struct {
+	name          string
+	txs           *types.TransactionsByPriceAndNonce
+	maxTxsPerList uint64
+	maxGasPerList uint64
+
+	txsUsed uint64
+	gasUsed uint64
+}
+
+// fits checks whether the given transaction fits in the lane's quotas of the
+// transactions list being built.
+func (l *txLane) fits(tx *types.Transaction) bool {
+	if l.maxTxsPerList != 0 && l.txsUsed >= l.maxTxsPerList {
+		return false
+	}
+	return l.maxGasPerList == 0 || l.gasUsed+tx.Gas() <= l.maxGasPerList
+}
+
+// txLaneScheduler merges the price and nonce sorted transactions of several lanes
+// into a single stream, following the lanes' quotas and fairness policy. The last
+// lane holds the transactions of the other senders, it has no quotas.
+type txLaneScheduler struct {
+	lanes      []*txLane
+	roundRobin bool
+	next       int // Lane the next transaction is searched from first
+	cur        int // Lane of the transaction returned by the last Peek
+}
+
+// newTxLaneScheduler creates a scheduler of the given pool content transactions,
+// split into the configured lanes, followed by the locals lane and the lane of
+// the other senders.
+func newTxLaneScheduler(chainID *big.Int, pc PoolContent, config *TxLanesConfig, locals []common.Address) *txLaneScheduler {
+	var (
+		lanes     = append(make([]*TxLane, 0, len(config.Lanes)+1), config.Lanes...)
+		laneOf    = make(map[common.Address]int)
+		signer    = types.LatestSignerForChainID(chainID)
+		scheduler = &txLaneScheduler{roundRobin: config.Policy == TxLanePolicyRoundRobin}
+	)
+	if len(locals) > 0 {
+		lanes = append(lanes, &TxLane{Name: localsTxLane, Senders: locals})
+	}
+	for i, lane := range lanes {
+		for _, sender := range lane.Senders {
+			// The configured lanes take precedence over the locals lane.
+			if _, ok := laneOf[sender]; !ok {
+				laneOf[sender] = i
+			}
+		}
+	}
+	laneTxs := make([]map[common.Address]types.Transactions, len(lanes)+1)
+	for i := range laneTxs {
+		laneTxs[i] = make(map[common.Address]types.Transactions)
+	}
+	for sender, txs := range pc {
+		i, ok := laneOf[sender]
+		if !ok {
+			i = len(lanes)
+		}
+		laneTxs[i][sender] = txs
+	}
+	for i, lane := range lanes {
+		scheduler.lanes = append(scheduler.lanes, &txLane{
+			name:          lane.Name,
+			txs:           types.NewTransactionsByPriceAndNonce(signer, laneTxs[i], nil),
+			maxTxsPerList: lane.MaxTxsPerList,
+			maxGasPerList: lane.MaxGasPerList,
+		})
+	}
+	scheduler.lanes = append(scheduler.lanes, &txLane{
+		txs: types.NewTransactionsByPriceAndNonce(signer, laneTxs[len(lanes)], nil),
+	})
+	return scheduler
+}
+
+// newSingleTxLaneScheduler creates a scheduler of a single lane without quotas.
+func newSingleTxLaneScheduler(txs *types.TransactionsByPriceAndNonce) *txLaneScheduler {
+	return &txLaneScheduler{lanes: []*txLane{{txs: txs}}}
+}
+
+// Peek returns the next transaction of the transactions list being built, from
+// the first lane within its quotas, in the policy's order. The transactions which
+// can never fit in their lane's gas quota are dropped, along with the following
+// transactions of their sender.
+func (s *txLaneScheduler) Peek() *types.Transaction {
+	for i := 0; i < len(s.lanes); i++ {
+		cur := (s.next + i) % len(s.lanes)
+		lane := s.lanes[cur]
+		for {
+			tx := lane.txs.Peek()
+			if tx == nil {
+				break
+			}
+			if lane.fits(tx) {
+				s.cur = cur
+				return tx
+			}
+			if lane.maxGasPerList == 0 || tx.Gas() <= lane.maxGasPerList {
+				break
+			}
+			log.Debug("Pending transaction exceeds txLane gas quota", "hash", tx.Hash(), "lane", lane.name, "gas", tx.Gas())
+			lane.txs.Pop()
+		}
+	}
+	return nil
+}
+
+// Shift accepts the transaction returned by the last Peek into the transactions
+// list being built, and replaces it with the next one of the same sender.
+func (s *txLaneScheduler) Shift() {
+	lane := s.lanes[s.cur]
+	if tx := lane.txs.Peek(); tx != nil {
+		lane.txsUsed++
+		lane.gasUsed += tx.Gas()
+	}
+	lane.txs.Shift()
+
+	if s.roundRobin {
+		s.next = (s.cur + 1) % len(s.lanes)
+	}
+}
+
+// Pop drops the transaction returned by the last Peek, along with the following
+// transactions of the same sender.
+func (s *txLaneScheduler) Pop() {
+	s.lanes[s.cur].txs.Pop()
+}
+
+// Lane returns the name of the lane of the transaction returned by the last Peek,
+// the transactions of the other senders having no lane.
+func (s *txLaneScheduler) Lane() string {
+	return s.lanes[s.cur].name
+}
+
+// NewList resets the lanes' usages, when a new transactions list is started.
+func (s *txLaneScheduler) NewList() {
+	for _, lane := range s.lanes {
+		lane.txsUsed, lane.gasUsed = 0, 0
+	}
+}
+
+// Pending checks whether any lane still has transactions, even beyond its quotas
+// of the transactions list being built.
+func (s *txLaneScheduler) Pending() bool {
+	for _, lane := range s.lanes {
+		if lane.txs.Peek() != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newTxLanesTestSender creates a sender with the given number of transfers.
+func newTxLanesTestSender(t *testing.T, signer types.Signer, txs int) (common.Address, types.Transactions) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	return crypto.PubkeyToAddress(key.PublicKey), newTxLanesTestTxs(signer, key, txs)
+}
+
+func newTxLanesTestTxs(signer types.Signer, key *ecdsa.PrivateKey, n int) types.Transactions {
+	txs := make(types.Transactions, n)
+	for i := range txs {
+		txs[i] = types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			Gas:      21000,
+			GasPrice: big.NewInt(1),
+		})
+	}
+	return txs
+}
+
+// txListSenders returns the sender of each transaction of the given list.
+func txListSenders(t *testing.T, signer types.Signer, txList *PreBuiltTxList) []common.Address {
+	senders := make([]common.Address, len(txList.TxList))
+	for i, tx := range txList.TxList {
+		sender, err := types.Sender(signer, tx)
+		require.Nil(t, err)
+		senders[i] = sender
+	}
+	return senders
+}
+
+func TestTxLanesConfigValidate(t *testing.T) {
+	sender := common.Address{0x01}
+
+	require.Nil(t, (&TxLanesConfig{
+		Lanes:  []*TxLane{{Name: "a", Senders: []common.Address{sender}}, {Name: "b"}},
+		Policy: TxLanePolicyRoundRobin,
+	}).Validate())
+
+	require.NotNil(t, (&TxLanesConfig{Policy: "unknown"}).Validate())
+	require.NotNil(t, (&TxLanesConfig{Lanes: []*TxLane{{}}}).Validate())
+	require.NotNil(t, (&TxLanesConfig{Lanes: []*TxLane{{Name: localsTxLane}}}).Validate())
+	require.NotNil(t, (&TxLanesConfig{Lanes: []*TxLane{{Name: "a"}, {Name: "a"}}}).Validate())
+	require.NotNil(t, (&TxLanesConfig{Lanes: []*TxLane{
+		{Name: "a", Senders: []common.Address{sender}},
+		{Name: "b", Senders: []common.Address{sender}},
+	}}).Validate())
+}
+
+func TestLoadTxLanesConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lanes.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{
+		"lanes": [{"name": "bridge", "senders": ["0x0000000000000000000000000000000000000001"], "maxTxsPerList": 2}],
+		"policy": "roundrobin"
+	}`), 0644))
+
+	config, err := LoadTxLanesConfig(path)
+	require.Nil(t, err)
+	require.Equal(t, &TxLanesConfig{
+		Lanes:  []*TxLane{{Name: "bridge", Senders: []common.Address{common.HexToAddress("0x01")}, MaxTxsPerList: 2}},
+		Policy: TxLanePolicyRoundRobin,
+	}, config)
+
+	require.Nil(t, os.WriteFile(path, []byte(`{"policy": "unknown"}`), 0644))
+	_, err = LoadTxLanesConfig(path)
+	require.NotNil(t, err)
+
+	// The configuration held by TxLanes can't be changed by its callers.
+	lanes, err := NewTxLanes(config)
+	require.Nil(t, err)
+	config.Lanes[0].MaxTxsPerList = 10
+	require.Equal(t, uint64(2), lanes.Config().Lanes[0].MaxTxsPerList)
+
+	require.NotNil(t, lanes.SetConfig(&TxLanesConfig{Policy: "unknown"}))
+	require.Nil(t, lanes.SetConfig(nil))
+	require.Nil(t, lanes.Config())
+}
+
+func TestPoolContentSplitTxLanes(t *testing.T) {
+	var (
+		chainID     = new(big.Int).SetUint64(1336)
+		signer      = types.LatestSignerForChainID(chainID)
+		a, aTxs     = newTxLanesTestSender(t, signer, 4)
+		b, bTxs     = newTxLanesTestSender(t, signer, 4)
+		c, cTxs     = newTxLanesTestSender(t, signer, 4)
+		content     = PoolContent{a: aTxs, b: bTxs, c: cTxs}
+		newSplitter = func(lanes *TxLanesConfig, locals []string) *PoolContentSplitter {
+			splitter, err := NewPoolContentSplitter(chainID, 5, 21000*10, 10000, 21000, locals, TxListCompressionNone, nil, lanes)
+			require.Nil(t, err)
+			return splitter
+		}
+	)
+
+	// With the priority policy, the lanes come first within their quotas, and
+	// the remaining space goes to the other senders.
+	splitter := newSplitter(&TxLanesConfig{Lanes: []*TxLane{
+		{Name: "builders", Senders: []common.Address{a}, MaxTxsPerList: 2},
+		{Name: "bridge", Senders: []common.Address{b}, MaxGasPerList: 21000},
+	}}, nil)
+	splitted := splitter.Split(content)
+	require.Len(t, splitted, 4)
+	require.Equal(t, []common.Address{a, a, b, c, c}, txListSenders(t, signer, splitted[0]))
+	require.Equal(t, []common.Address{a, a, b, c, c}, txListSenders(t, signer, splitted[1]))
+	require.Equal(t, []common.Address{b}, txListSenders(t, signer, splitted[2]))
+	require.Equal(t, []common.Address{b}, txListSenders(t, signer, splitted[3]))
+	require.Equal(t, []*TxLaneUsage{
+		{Lane: "builders", Transactions: 2, Gas: 42000},
+		{Lane: "bridge", Transactions: 1, Gas: 21000},
+	}, splitted[0].Lanes)
+
+	truncated := splitter.Truncate(splitted[0], 2)
+	require.Equal(t, []*TxLaneUsage{{Lane: "builders", Transactions: 2, Gas: 42000}}, truncated.Lanes)
+
+	// With the round-robin policy, the lanes and the other senders take turns.
+	splitter = newSplitter(&TxLanesConfig{
+		Lanes:  []*TxLane{{Name: "builders", Senders: []common.Address{a}}},
+		Policy: TxLanePolicyRoundRobin,
+	}, nil)
+	splitted = splitter.Split(PoolContent{a: aTxs, c: cTxs})
+	require.Len(t, splitted, 2)
+	require.Equal(t, []common.Address{a, c, a, c, a}, txListSenders(t, signer, splitted[0]))
+	require.Equal(t, []common.Address{c, a, c}, txListSenders(t, signer, splitted[1]))
+	require.Equal(t, []*TxLaneUsage{{Lane: "builders", Transactions: 3, Gas: 63000}}, splitted[0].Lanes)
+
+	// The locals of a call make an extra lane, after the configured ones.
+	splitter = newSplitter(&TxLanesConfig{
+		Lanes: []*TxLane{{Name: "builders", Senders: []common.Address{a}, MaxTxsPerList: 1}},
+	}, []string{c.Hex()})
+	splitted = splitter.Split(content)
+	require.Equal(t, []common.Address{a, c, c, c, c}, txListSenders(t, signer, splitted[0]))
+	require.Equal(t, []*TxLaneUsage{
+		{Lane: "builders", Transactions: 1, Gas: 21000},
+		{Lane: localsTxLane, Transactions: 4, Gas: hexutil.Uint64(4 * 21000)},
+	}, splitted[0].Lanes)
+
+	// The transactions which can never fit in their lane's gas quota are dropped.
+	splitter = newSplitter(&TxLanesConfig{
+		Lanes: []*TxLane{{Name: "bridge", Senders: []common.Address{b}, MaxGasPerList: 20000}},
+	}, nil)
+	splitted = splitter.Split(PoolContent{b: bTxs, c: cTxs})
+	require.Len(t, splitted, 1)
+	require.Equal(t, []common.Address{c, c, c, c}, txListSenders(t, signer, splitted[0]))
+	require.Empty(t, splitted[0].Lanes)
+
+	// Without lanes, no usage is reported.
+	splitted = newSplitter(nil, nil).Split(content)
+	for _, txList := range splitted {
+		require.Nil(t, txList.Lanes)
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	ErrTxListMalformed  = errors.New("malformed txList")
+	ErrTxListTooLarge   = errors.New("txList too large")
+	ErrTxListTooManyTxs = errors.New("too many transactions in txList")
+
+	// ErrTxListAnchorMissing is returned when not even the anchor transaction of a
+	// txList can be decoded, including for an empty txList.
+	ErrTxListAnchorMissing = fmt.Errorf("%w: anchor transaction missing", ErrTxListMalformed)
+)
+
+// DecodeTxList decodes the txList of a proposed L2 block. Instead of rejecting the
+// whole list, a malformed txList is salvaged, so that the chain keeps advancing
+// deterministically:
+//
+//   - the well-formed transactions before the first malformed one are kept, the
+//     rest of the list is dropped.
+//   - only the anchor transaction of a txList larger than the protocol limit is kept.
+//   - the transactions following the anchor transaction beyond the protocol limit
+//     are dropped.
+//
+// The dropped transactions are returned with the reason they were dropped, the
+// malformed part of the list is reported as a single entry, hashed over its raw
+// bytes.
+//
+// ErrTxListAnchorMissing is only returned if not even the anchor transaction is
+// decoded. The anchor transaction isn't part of the txList proposed on L1, it is
+// built and prepended by the driver, which replaces an undecodable proposed txList
+// by an empty one. So a txList without anchor is never derived from L1, and the
+// payload attributes carrying it are rejected as invalid instead of stalling the
+// chain on a proposed block.
+func DecodeTxList(config *params.ChainConfig, txList []byte) (types.Transactions, []*rawdb.SkippedTransaction, error) {
+	content, trailing, err := txListContent(txList)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrTxListAnchorMissing, err)
+	}
+	var (
+		txs     types.Transactions
+		dropped []*rawdb.SkippedTransaction
+	)
+	for len(content) > 0 {
+		_, _, rest, err := rlp.Split(content)
+		var tx types.Transaction
+		if err == nil {
+			err = rlp.DecodeBytes(content[:len(content)-len(rest)], &tx)
+		}
+		if err != nil {
+			dropped = append(dropped, &rawdb.SkippedTransaction{
+				Index:  uint64(len(txs)),
+				Hash:   crypto.Keccak256Hash(content),
+				Reason: fmt.Errorf("%w: %v", ErrTxListMalformed, err).Error(),
+			})
+			break
+		}
+		txs = append(txs, &tx)
+		content = rest
+	}
+	if len(trailing) > 0 {
+		dropped = append(dropped, &rawdb.SkippedTransaction{
+			Index:  uint64(len(txs)),
+			Hash:   crypto.Keccak256Hash(trailing),
+			Reason: fmt.Errorf("%w: %d bytes after the list", ErrTxListMalformed, len(trailing)).Error(),
+		})
+	}
+	if len(txs) == 0 {
+		return nil, nil, ErrTxListAnchorMissing
+	}
+
+	// Apply the protocol limits, the anchor transaction is always kept.
+	var (
+		limits = config.TaikoParams()
+		keep   = len(txs)
+		reason error
+	)
+	switch {
+	case limits.BlockMaxTxListBytes != 0 && uint64(len(txList)) > limits.BlockMaxTxListBytes:
+		keep, reason = 1, fmt.Errorf("%w: %d bytes, limit %d", ErrTxListTooLarge, len(txList), limits.BlockMaxTxListBytes)
+	case limits.BlockMaxTransactions != 0 && uint64(len(txs)-1) > limits.BlockMaxTransactions:
+		keep, reason = int(limits.BlockMaxTransactions)+1, fmt.Errorf("%w: limit %d", ErrTxListTooManyTxs, limits.BlockMaxTransactions)
+	}
+	if keep < len(txs) {
+		limited := make([]*rawdb.SkippedTransaction, 0, len(txs)-keep+len(dropped))
+		for i, tx := range txs[keep:] {
+			limited = append(limited, &rawdb.SkippedTransaction{Index: uint64(keep + i), Hash: tx.Hash(), Reason: reason.Error()})
+		}
+		txs, dropped = txs[:keep], append(limited, dropped...)
+	}
+	return txs, dropped, nil
+}
+
+// txListContent returns the encoded transactions of the given txList, and the
+// bytes following the list. The list header is tolerated to declare more bytes
+// than available.
+func txListContent(txList []byte) ([]byte, []byte, error) {
+	if len(txList) == 0 || txList[0] < 0xC0 {
+		return nil, nil, errors.New("not a list")
+	}
+	content, rest, err := rlp.SplitList(txList)
+	if err == nil {
+		return content, rest, nil
+	}
+	// Skip the list header, a short list header is a single byte, a long list
+	// header is followed by the size of the list length.
+	header := 1
+	if txList[0] > 0xF7 {
+		header += int(txList[0] - 0xF7)
+	}
+	if header > len(txList) {
+		return nil, nil, err
+	}
+	return txList[header:], nil, nil
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTxListTestTxs(t *testing.T, n int) types.Transactions {
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSigner(params.TestChainConfig)
+
+	txs := make(types.Transactions, n)
+	for i := range txs {
+		txs[i] = types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		})
+	}
+	return txs
+}
+
+func encodeTxList(t *testing.T, txs types.Transactions) []byte {
+	txList, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+	return txList
+}
+
+func requireTxHashes(t *testing.T, expected, actual types.Transactions) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].Hash(), actual[i].Hash())
+	}
+}
+
+func TestDecodeTxList(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		txs    = newTxListTestTxs(t, 4)
+		txList = encodeTxList(t, txs)
+	)
+	config.Taiko = true
+
+	// A well-formed txList is decoded as is.
+	decoded, dropped, err := DecodeTxList(&config, txList)
+	require.Nil(t, err)
+	require.Empty(t, dropped)
+	requireTxHashes(t, txs, decoded)
+
+	// A truncated txList keeps the transactions before the truncated one.
+	decoded, dropped, err = DecodeTxList(&config, txList[:len(txList)-10])
+	require.Nil(t, err)
+	requireTxHashes(t, txs[:3], decoded)
+	require.Len(t, dropped, 1)
+	require.Equal(t, uint64(3), dropped[0].Index)
+	require.Contains(t, dropped[0].Reason, ErrTxListMalformed.Error())
+
+	// Garbage within the list keeps the transactions before the garbage.
+	content, _, err := rlp.SplitList(encodeTxList(t, txs[:2]))
+	require.Nil(t, err)
+	content = append(common.CopyBytes(content), 0xff, 0x01, 0x02)
+	garbage := append([]byte{0xf9, byte(len(content) >> 8), byte(len(content))}, content...)
+	decoded, dropped, err = DecodeTxList(&config, garbage)
+	require.Nil(t, err)
+	requireTxHashes(t, txs[:2], decoded)
+	require.Len(t, dropped, 1)
+	require.Equal(t, uint64(2), dropped[0].Index)
+	require.Equal(t, crypto.Keccak256Hash([]byte{0xff, 0x01, 0x02}), dropped[0].Hash)
+
+	// The bytes following the list are reported as dropped.
+	decoded, dropped, err = DecodeTxList(&config, append(common.CopyBytes(txList), 0x01, 0x02))
+	require.Nil(t, err)
+	requireTxHashes(t, txs, decoded)
+	require.Len(t, dropped, 1)
+	require.Equal(t, uint64(4), dropped[0].Index)
+	require.Equal(t, crypto.Keccak256Hash([]byte{0x01, 0x02}), dropped[0].Hash)
+
+	// A txList which can't even be decoded up to the anchor transaction is rejected.
+	_, _, err = DecodeTxList(&config, []byte{0x01})
+	require.ErrorIs(t, err, ErrTxListMalformed)
+	_, _, err = DecodeTxList(&config, []byte{0xc2, 0xff, 0xff})
+	require.ErrorIs(t, err, ErrTxListMalformed)
+	_, _, err = DecodeTxList(&config, []byte{0xc0})
+	require.ErrorIs(t, err, ErrTxListMalformed)
+	for _, txList := range [][]byte{nil, {0x01}, {0xc2, 0xff, 0xff}, {0xc0}} {
+		_, _, err = DecodeTxList(&config, txList)
+		require.ErrorIs(t, err, ErrTxListAnchorMissing)
+	}
+}
+
+func TestDecodeTxListLimits(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		txs    = newTxListTestTxs(t, 4)
+		txList = encodeTxList(t, txs)
+	)
+	config.Taiko = true
+
+	// Only the anchor transaction of a too large txList is kept.
+	config.TaikoConfig = &params.TaikoConfig{BlockMaxTxListBytes: uint64(len(txList) - 1)}
+	decoded, dropped, err := DecodeTxList(&config, txList)
+	require.Nil(t, err)
+	requireTxHashes(t, txs[:1], decoded)
+	require.Len(t, dropped, 3)
+	for i, tx := range dropped {
+		require.Equal(t, uint64(i+1), tx.Index)
+		require.Equal(t, txs[i+1].Hash(), tx.Hash)
+		require.Contains(t, tx.Reason, ErrTxListTooLarge.Error())
+	}
+
+	// The transactions beyond the limit are dropped, the anchor transaction
+	// excluded.
+	config.TaikoConfig = &params.TaikoConfig{BlockMaxTransactions: 2}
+	decoded, dropped, err = DecodeTxList(&config, txList)
+	require.Nil(t, err)
+	requireTxHashes(t, txs[:3], decoded)
+	require.Len(t, dropped, 1)
+	require.Equal(t, uint64(3), dropped[0].Index)
+	require.Equal(t, txs[3].Hash(), dropped[0].Hash)
+	require.Contains(t, dropped[0].Reason, ErrTxListTooManyTxs.Error())
+
+	config.TaikoConfig = &params.TaikoConfig{BlockMaxTransactions: 3}
+	decoded, dropped, err = DecodeTxList(&config, txList)
+	require.Nil(t, err)
+	requireTxHashes(t, txs, decoded)
+	require.Empty(t, dropped)
+}
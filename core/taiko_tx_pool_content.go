@@ -0,0 +1,34 @@
+package core
+
+// TxPoolContentOptions are the optional settings of a `taiko_txPoolContentWithOptions`
+// call.
+type TxPoolContentOptions struct {
+	// Compression is the compression mode of the transactions lists posted to L1,
+	// the lists are packed against their compressed size, defaults to none.
+	Compression string `json:"compression"`
+
+	// Simulate makes each candidate transaction executed against the current head
+	// state, the ones which would be skipped when sealing a block are dropped, and
+	// the lists are packed on their actual gas used.
+	Simulate bool `json:"simulate"`
+}
+
+// TxPoolContentLimits are the settings of a `taiko_subscribe("txPoolContent")`
+// subscription.
+type TxPoolContentLimits struct {
+	MaxTransactionsPerBlock uint64   `json:"maxTransactionsPerBlock"`
+	BlockMaxGasLimit        uint64   `json:"blockMaxGasLimit"`
+	MaxBytesPerTxList       uint64   `json:"maxBytesPerTxList"`
+	MinTxGasLimit           uint64   `json:"minTxGasLimit"`
+	Locals                  []string `json:"locals"`
+
+	// MinTxsPerList is the minimum number of transactions the first list must
+	// contain before the lists are pushed.
+	MinTxsPerList uint64 `json:"minTxsPerList"`
+
+	// Debounce is the time in milliseconds the transaction pool changes are batched
+	// for before the lists are rebuilt, defaults to 200.
+	Debounce uint64 `json:"debounce"`
+
+	TxPoolContentOptions
+}
@@ -0,0 +1,350 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	ErrWitnessMissingParent = errors.New("witness misses the parent header")
+	ErrWitnessInvalidHeader = errors.New("witness headers are not a chain of ancestors")
+)
+
+// Witness contains everything needed to re-execute a block without access to the
+// chain database: the parent header and the ancestor headers read by BLOCKHASH,
+// the trie nodes of every account and storage slot touched by the block, and the
+// code of every contract it touched.
+//
+// The witness is self-verifying: the headers are linked to the block through
+// their parent hashes, the trie nodes and codes are addressed by their hashes,
+// starting from the parent state root. A tampered or incomplete witness makes
+// the re-execution fail.
+type Witness struct {
+	// Headers are the parent header, followed by the ancestors needed by BLOCKHASH
+	// in descending order.
+	Headers []*types.Header `json:"headers"`
+	Codes   []hexutil.Bytes `json:"codes"`
+	State   []hexutil.Bytes `json:"state"`
+}
+
+// BlockWitness re-executes the given block on top of its parent state, and returns
+// the witness of all the state it accessed. The parent state must be available.
+func (bc *BlockChain) BlockWitness(block *types.Block) (*Witness, error) {
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	if !bc.HasState(parent.Root) {
+		return nil, fmt.Errorf("missing state of parent block %d", parent.Number)
+	}
+
+	var (
+		recorder = newWitnessRecorder(bc.stateCache)
+		chain    = &headerRecorder{BlockChain: bc, headers: make(map[common.Hash]*types.Header)}
+	)
+	statedb, err := state.New(parent.Root, recorder, nil)
+	if err != nil {
+		return nil, err
+	}
+	root, _, _, err := applyBlockStateless(bc.chainConfig, chain, block, statedb, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if err := statedb.Error(); err != nil {
+		return nil, err
+	}
+	if root != block.Root() {
+		return nil, fmt.Errorf("state root mismatch after re-execution: have %x, want %x", root, block.Root())
+	}
+
+	witness := &Witness{Headers: []*types.Header{parent}}
+	for _, header := range chain.ancestors(parent) {
+		witness.Headers = append(witness.Headers, header)
+	}
+	witness.Codes, witness.State = recorder.witness()
+
+	return witness, nil
+}
+
+// ExecuteBlockWithWitness re-executes the given block using only the state and
+// the ancestor headers in the witness, and checks the resulting gas used, receipts
+// and state root against the block. The receipts of the block are returned.
+func ExecuteBlockWithWitness(config *params.ChainConfig, engine consensus.Engine, block *types.Block, witness *Witness) (types.Receipts, error) {
+	chain, err := newWitnessChain(config, engine, block, witness.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	db := rawdb.NewMemoryDatabase()
+	for _, node := range witness.State {
+		rawdb.WriteLegacyTrieNode(db, crypto.Keccak256Hash(node), node)
+	}
+	for _, code := range witness.Codes {
+		rawdb.WriteCode(db, crypto.Keccak256Hash(code), code)
+	}
+	statedb, err := state.New(chain.parent.Root, state.NewDatabase(db), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root, receipts, usedGas, err := applyBlockStateless(config, chain, block, statedb, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	// Missing trie nodes or codes aren't reported by the state operations, but
+	// are remembered in the state database.
+	if err := statedb.Error(); err != nil {
+		return nil, fmt.Errorf("incomplete witness: %w", err)
+	}
+
+	if usedGas != block.GasUsed() {
+		return nil, fmt.Errorf("invalid gas used (remote: %d local: %d)", block.GasUsed(), usedGas)
+	}
+	if receiptSha := types.DeriveSha(receipts, trie.NewStackTrie(nil)); receiptSha != block.ReceiptHash() {
+		return nil, fmt.Errorf("invalid receipt root hash (remote: %x local: %x)", block.ReceiptHash(), receiptSha)
+	}
+	if root != block.Root() {
+		return nil, fmt.Errorf("invalid merkle root (remote: %x local: %x)", block.Root(), root)
+	}
+	return receipts, nil
+}
+
+// executionChain is the chain access needed to execute a block.
+type executionChain interface {
+	ChainContext
+	consensus.ChainHeaderReader
+}
+
+// applyBlockStateless executes the given block on top of the given state with a
+// StateProcessor backed by the given chain, and returns the resulting state root,
+// the receipts and the gas used.
+func applyBlockStateless(config *params.ChainConfig, chain executionChain, block *types.Block, statedb *state.StateDB, cfg vm.Config) (common.Hash, types.Receipts, uint64, error) {
+	processor := &StateProcessor{config: config, bc: chain, engine: chain.Engine()}
+
+	receipts, _, usedGas, err := processor.Process(block, statedb, cfg)
+	if err != nil {
+		return common.Hash{}, nil, 0, err
+	}
+	return statedb.IntermediateRoot(config.IsEIP158(block.Number())), receipts, usedGas, nil
+}
+
+// witnessRecorder is a state database recording all the tries it opens and all
+// the contract codes read through it.
+type witnessRecorder struct {
+	state.Database
+
+	tries []state.Trie
+	codes map[common.Hash][]byte
+	lock  sync.Mutex
+}
+
+func newWitnessRecorder(db state.Database) *witnessRecorder {
+	return &witnessRecorder{
+		Database: db,
+		codes:    make(map[common.Hash][]byte),
+	}
+}
+
+// OpenTrie opens the main account trie, and records it.
+func (r *witnessRecorder) OpenTrie(root common.Hash) (state.Trie, error) {
+	tr, err := r.Database.OpenTrie(root)
+	if err != nil {
+		return nil, err
+	}
+	return r.recordTrie(tr), nil
+}
+
+// OpenStorageTrie opens the storage trie of an account, and records it.
+func (r *witnessRecorder) OpenStorageTrie(stateRoot common.Hash, addrHash, root common.Hash) (state.Trie, error) {
+	tr, err := r.Database.OpenStorageTrie(stateRoot, addrHash, root)
+	if err != nil {
+		return nil, err
+	}
+	return r.recordTrie(tr), nil
+}
+
+// CopyTrie returns an independent copy of the given trie, and records it.
+func (r *witnessRecorder) CopyTrie(tr state.Trie) state.Trie {
+	return r.recordTrie(r.Database.CopyTrie(tr))
+}
+
+// ContractCode retrieves a particular contract's code, and records it.
+func (r *witnessRecorder) ContractCode(addrHash, codeHash common.Hash) ([]byte, error) {
+	code, err := r.Database.ContractCode(addrHash, codeHash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	r.codes[codeHash] = code
+	r.lock.Unlock()
+
+	return code, nil
+}
+
+// ContractCodeSize retrieves a particular contract's code size, the whole code is
+// recorded, since it's needed to know the size in the stateless re-execution.
+func (r *witnessRecorder) ContractCodeSize(addrHash, codeHash common.Hash) (int, error) {
+	code, err := r.ContractCode(addrHash, codeHash)
+	return len(code), err
+}
+
+func (r *witnessRecorder) recordTrie(tr state.Trie) state.Trie {
+	r.lock.Lock()
+	r.tries = append(r.tries, tr)
+	r.lock.Unlock()
+
+	return tr
+}
+
+// witness returns the recorded codes and the trie nodes loaded by the recorded
+// tries, deduplicated and sorted by their hashes.
+func (r *witnessRecorder) witness() ([]hexutil.Bytes, []hexutil.Bytes) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	nodes := make(map[common.Hash][]byte)
+	for _, tr := range r.tries {
+		if tr, ok := tr.(interface{ Witness() [][]byte }); ok {
+			for _, node := range tr.Witness() {
+				nodes[crypto.Keccak256Hash(node)] = node
+			}
+		}
+	}
+	return sortedByHash(r.codes), sortedByHash(nodes)
+}
+
+// sortedByHash returns the values of the given map, sorted by their keys.
+func sortedByHash(blobs map[common.Hash][]byte) []hexutil.Bytes {
+	hashes := make([]common.Hash, 0, len(blobs))
+	for hash := range blobs {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+	sorted := make([]hexutil.Bytes, 0, len(hashes))
+	for _, hash := range hashes {
+		sorted = append(sorted, common.CopyBytes(blobs[hash]))
+	}
+	return sorted
+}
+
+// headerRecorder is a chain recording all the headers retrieved through
+// GetHeader, i.e. the ancestors read by BLOCKHASH.
+type headerRecorder struct {
+	*BlockChain
+	headers map[common.Hash]*types.Header
+}
+
+// GetHeader retrieves a block header from the database by hash and number, and
+// records it.
+func (r *headerRecorder) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header := r.BlockChain.GetHeader(hash, number)
+	if header != nil {
+		r.headers[hash] = header
+	}
+	return header
+}
+
+// ancestors returns the recorded headers older than the given parent, as a chain
+// of ancestors in descending order.
+func (r *headerRecorder) ancestors(parent *types.Header) []*types.Header {
+	var (
+		ancestors []*types.Header
+		lowest    = parent.Number.Uint64()
+	)
+	for _, header := range r.headers {
+		if number := header.Number.Uint64(); number < lowest {
+			lowest = number
+		}
+	}
+	for header := parent; header.Number.Uint64() > lowest; {
+		header = r.BlockChain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+		if header == nil {
+			break
+		}
+		ancestors = append(ancestors, header)
+	}
+	return ancestors
+}
+
+// witnessChain is a chain only made of the headers in a witness.
+type witnessChain struct {
+	config  *params.ChainConfig
+	engine  consensus.Engine
+	parent  *types.Header
+	headers map[common.Hash]*types.Header
+}
+
+// newWitnessChain checks that the given headers are the parent of the block and
+// its ancestors, and returns a chain made of them.
+func newWitnessChain(config *params.ChainConfig, engine consensus.Engine, block *types.Block, headers []*types.Header) (*witnessChain, error) {
+	if len(headers) == 0 || headers[0].Hash() != block.ParentHash() {
+		return nil, ErrWitnessMissingParent
+	}
+	chain := &witnessChain{
+		config:  config,
+		engine:  engine,
+		parent:  headers[0],
+		headers: make(map[common.Hash]*types.Header, len(headers)),
+	}
+	for i, header := range headers {
+		if i > 0 && header.Hash() != headers[i-1].ParentHash {
+			return nil, fmt.Errorf("%w: header %d", ErrWitnessInvalidHeader, i)
+		}
+		chain.headers[header.Hash()] = header
+	}
+	return chain, nil
+}
+
+// Config retrieves the chain configuration.
+func (c *witnessChain) Config() *params.ChainConfig { return c.config }
+
+// Engine retrieves the chain's consensus engine.
+func (c *witnessChain) Engine() consensus.Engine { return c.engine }
+
+// CurrentHeader returns the parent of the re-executed block.
+func (c *witnessChain) CurrentHeader() *types.Header { return c.parent }
+
+// GetHeader retrieves a witness header by hash and number.
+func (c *witnessChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+// GetHeaderByHash retrieves a witness header by hash.
+func (c *witnessChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.headers[hash]
+}
+
+// GetHeaderByNumber retrieves a witness header by number.
+func (c *witnessChain) GetHeaderByNumber(number uint64) *types.Header {
+	for _, header := range c.headers {
+		if header.Number.Uint64() == number {
+			return header
+		}
+	}
+	return nil
+}
+
+// GetTd is not available in a witness.
+func (c *witnessChain) GetTd(hash common.Hash, number uint64) *big.Int {
+	return nil
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockWitness(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xcc")
+		other    = common.HexToAddress("0xdd")
+		config   = params.TestChainConfig
+		engine   = ethash.NewFaker()
+	)
+	// The contract increments slot 0, stores BLOCKHASH(0) in slot 1, and the code
+	// size of the other contract in slot 2.
+	code := []byte{
+		byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.PUSH1), 1, byte(vm.ADD), byte(vm.PUSH1), 0, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0, byte(vm.BLOCKHASH), byte(vm.PUSH1), 1, byte(vm.SSTORE),
+		byte(vm.PUSH20),
+	}
+	code = append(code, other.Bytes()...)
+	code = append(code, byte(vm.EXTCODESIZE), byte(vm.PUSH1), 2, byte(vm.SSTORE), byte(vm.STOP))
+
+	genesis := &Genesis{
+		Config: config,
+		Alloc: GenesisAlloc{
+			sender:   {Balance: big.NewInt(params.Ether)},
+			contract: {Balance: common.Big0, Code: code, Storage: map[common.Hash]common.Hash{{}: common.BigToHash(common.Big1)}},
+			other:    {Balance: common.Big0, Code: []byte{byte(vm.STOP), byte(vm.STOP)}},
+		},
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	// The blocks are generated one by one on top of the chain, so that BLOCKHASH
+	// can be resolved while generating them.
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, &CacheConfig{TrieDirtyDisabled: true}, genesis, nil, engine, vm.Config{}, nil, nil)
+	require.Nil(t, err)
+	defer chain.Stop()
+
+	var blocks []*types.Block
+	for i, parent := 0, chain.Genesis(); i < 4; i, parent = i+1, blocks[i] {
+		generated, _ := GenerateChain(config, parent, engine, db, 1, func(_ int, b *BlockGen) {
+			b.AddTxWithChain(chain, types.MustSignNewTx(key, types.LatestSigner(config), &types.LegacyTx{
+				Nonce:    uint64(i),
+				To:       &contract,
+				Gas:      100_000,
+				GasPrice: b.BaseFee(),
+			}))
+		})
+		_, err = chain.InsertChain(generated)
+		require.Nil(t, err)
+		blocks = append(blocks, generated...)
+	}
+
+	block := blocks[3]
+	witness, err := chain.BlockWitness(block)
+	require.Nil(t, err)
+
+	// BLOCKHASH(0) walks back from the parent to the block 1.
+	require.Len(t, witness.Headers, 3)
+	require.Equal(t, block.ParentHash(), witness.Headers[0].Hash())
+	require.Equal(t, uint64(1), witness.Headers[2].Number.Uint64())
+	require.Len(t, witness.Codes, 2)
+	require.NotEmpty(t, witness.State)
+
+	receipts, err := ExecuteBlockWithWitness(config, engine, block, witness)
+	require.Nil(t, err)
+	require.Len(t, receipts, 1)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipts[0].Status)
+
+	// A witness with missing state can't be used to re-execute the block.
+	incomplete := *witness
+	incomplete.State = witness.State[1:]
+	_, err = ExecuteBlockWithWitness(config, engine, block, &incomplete)
+	require.NotNil(t, err)
+
+	// Neither can a witness with missing code.
+	incomplete = *witness
+	incomplete.Codes = witness.Codes[1:]
+	_, err = ExecuteBlockWithWitness(config, engine, block, &incomplete)
+	require.NotNil(t, err)
+
+	// The headers must be the ancestors of the block.
+	incomplete = *witness
+	incomplete.Headers = witness.Headers[1:]
+	_, err = ExecuteBlockWithWitness(config, engine, block, &incomplete)
+	require.ErrorIs(t, err, ErrWitnessMissingParent)
+
+	incomplete = *witness
+	incomplete.Headers = []*types.Header{witness.Headers[0], witness.Headers[2]}
+	_, err = ExecuteBlockWithWitness(config, engine, block, &incomplete)
+	require.ErrorIs(t, err, ErrWitnessInvalidHeader)
+}
@@ -0,0 +1,115 @@
+package txpool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// defaultBlockMaxGasLimit is the default maximum gas limit of a proposed L2
+// block, LibSharedConfig.blockMaxGasLimit defined in protocol.
+const defaultBlockMaxGasLimit = 6000000
+
+// ProtocolLimits are the limits of the Taiko protocol on the transactions of a
+// proposed txList, the transactions beyond them would always be dropped by the
+// proposer, so they are rejected by the pool.
+type ProtocolLimits struct {
+	MinTxGasLimit     uint64 // Minimum gas limit of a transaction, zero means no limit
+	BlockMaxGasLimit  uint64 // Maximum gas limit of a block, zero means the protocol default
+	MaxBytesPerTxList uint64 // Maximum size of a txList's encoded bytes, zero means the chain's BlockMaxTxListBytes
+	TxListCompression string // Compression mode of the txList's encoded bytes
+}
+
+// sanitize checks the provided protocol limits and changes anything that's
+// unreasonable or unworkable.
+func (limits ProtocolLimits) sanitize() ProtocolLimits {
+	if _, err := core.TxListSize(limits.TxListCompression, nil); err != nil {
+		log.Warn("Sanitizing invalid txpool txList compression", "provided", limits.TxListCompression, "updated", core.TxListCompressionNone)
+		limits.TxListCompression = core.TxListCompressionNone
+	}
+	if limits.BlockMaxGasLimit != 0 && limits.MinTxGasLimit > limits.BlockMaxGasLimit {
+		log.Warn("Sanitizing invalid txpool min tx gas limit", "provided", limits.MinTxGasLimit, "updated", limits.BlockMaxGasLimit)
+		limits.MinTxGasLimit = limits.BlockMaxGasLimit
+	}
+	return limits
+}
+
+// blockMaxGasLimit returns the maximum gas limit of a proposed block.
+func (limits ProtocolLimits) blockMaxGasLimit() uint64 {
+	if limits.BlockMaxGasLimit == 0 {
+		return defaultBlockMaxGasLimit
+	}
+	return limits.BlockMaxGasLimit
+}
+
+// ProtocolLimits returns the protocol limits enforced by the transaction pool.
+func (pool *TxPool) ProtocolLimits() ProtocolLimits {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.config.ProtocolLimits
+}
+
+// SetProtocolLimits updates the protocol limits enforced by the transaction pool
+// for a new transaction, and drops all the transactions beyond them.
+func (pool *TxPool) SetProtocolLimits(limits ProtocolLimits) {
+	limits = limits.sanitize()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.config.ProtocolLimits = limits
+	if !pool.chainconfig.Taiko {
+		return
+	}
+	pool.currentMaxGas = limits.blockMaxGasLimit()
+
+	// Remove the transactions beyond the new limits, both local and remote ones,
+	// as they can never be proposed.
+	var drop []common.Hash
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction, local bool) bool {
+		if tx.Gas() > pool.currentMaxGas || pool.validateProtocolLimits(tx) != nil {
+			drop = append(drop, hash)
+		}
+		return true
+	}, true, true)
+
+	for _, hash := range drop {
+		pool.removeTx(hash, true)
+	}
+
+	log.Info("Transaction pool protocol limits updated", "minTxGasLimit", limits.MinTxGasLimit,
+		"blockMaxGasLimit", pool.currentMaxGas, "maxBytesPerTxList", pool.maxBytesPerTxList(), "dropped", len(drop))
+}
+
+// maxBytesPerTxList returns the maximum size of a proposed txList's encoded bytes,
+// zero means no limit.
+func (pool *TxPool) maxBytesPerTxList() uint64 {
+	if limit := pool.config.ProtocolLimits.MaxBytesPerTxList; limit != 0 {
+		return limit
+	}
+	return pool.chainconfig.TaikoParams().BlockMaxTxListBytes
+}
+
+// validateProtocolLimits checks whether a transaction could be proposed in a
+// txList according to the protocol limits, the block gas limit is checked by
+// the caller against the pool's current max gas.
+func (pool *TxPool) validateProtocolLimits(tx *types.Transaction) error {
+	limits := pool.config.ProtocolLimits
+	if tx.Gas() < limits.MinTxGasLimit {
+		return fmt.Errorf("%w: have %d, want %d", ErrTxGasLimitTooLow, tx.Gas(), limits.MinTxGasLimit)
+	}
+	if limit := pool.maxBytesPerTxList(); limit != 0 {
+		size, fits, err := core.TxListFits(limits.TxListCompression, []*types.Transaction{tx}, limit)
+		if err != nil {
+			return err
+		}
+		if !fits {
+			return fmt.Errorf("%w: have %d, want %d", ErrTxListOversized, size, limit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package txpool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestAnchorSenderTransactions(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.Taiko = true
+
+	pool, key := setupPoolWithConfig(&config)
+	defer pool.Stop()
+
+	taikoConfig := *params.DefaultTaikoConfig
+	taikoConfig.AnchorSender = crypto.PubkeyToAddress(key.PublicKey)
+	config.TaikoConfig = &taikoConfig
+
+	testAddBalance(pool, taikoConfig.AnchorSender, big.NewInt(1000000000))
+	if err := pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(1), key)); !errors.Is(err, ErrAnchorSender) {
+		t.Fatalf("expected %v, got %v", ErrAnchorSender, err)
+	}
+	if err := pool.AddLocal(pricedTransaction(0, 100000, big.NewInt(1), key)); !errors.Is(err, ErrAnchorSender) {
+		t.Fatalf("expected %v, got %v", ErrAnchorSender, err)
+	}
+
+	// Transactions from other senders are still accepted.
+	otherKey, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(otherKey.PublicKey), big.NewInt(1000000000))
+	if err := pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(1), otherKey)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+}
+
+func TestProtocolLimits(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.Taiko = true
+
+	poolConfig := testTxPoolConfig
+	poolConfig.ProtocolLimits = ProtocolLimits{
+		MinTxGasLimit:     21000,
+		BlockMaxGasLimit:  500000,
+		MaxBytesPerTxList: 1024,
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	pool := NewTxPool(poolConfig, &config, newTestBlockChain(10000000, statedb, new(event.Feed)))
+	<-pool.initDoneCh
+	defer pool.Stop()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000000))
+
+	// Transactions beyond the protocol limits are rejected at admission.
+	if err := pool.addRemoteSync(pricedTransaction(0, 20999, big.NewInt(1), key)); !errors.Is(err, ErrTxGasLimitTooLow) {
+		t.Fatalf("expected %v, got %v", ErrTxGasLimitTooLow, err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 500001, big.NewInt(1), key)); !errors.Is(err, ErrGasLimit) {
+		t.Fatalf("expected %v, got %v", ErrGasLimit, err)
+	}
+	if err := pool.addRemoteSync(pricedDataTransaction(0, 500000, big.NewInt(1), key, 1024)); !errors.Is(err, ErrTxListOversized) {
+		t.Fatalf("expected %v, got %v", ErrTxListOversized, err)
+	}
+
+	// Transactions within the limits are accepted.
+	if err := pool.addRemoteSync(pricedTransaction(0, 21000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedDataTransaction(1, 100000, big.NewInt(1), key, 512)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(2, 400000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 3)
+	}
+
+	// Tightening the limits drops the pooled transactions beyond them.
+	pool.SetProtocolLimits(ProtocolLimits{
+		MinTxGasLimit:     21000,
+		BlockMaxGasLimit:  300000,
+		MaxBytesPerTxList: 256,
+	})
+	pending, queued := pool.Stats()
+	if pending != 1 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 1)
+	}
+	if queued != 0 {
+		t.Fatalf("queued transactions mismatched: have %d, want %d", queued, 0)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 300001, big.NewInt(1), key)); !errors.Is(err, ErrGasLimit) {
+		t.Fatalf("expected %v, got %v", ErrGasLimit, err)
+	}
+
+	// Raising the minimum gas limit drops the remaining transaction.
+	pool.SetProtocolLimits(ProtocolLimits{MinTxGasLimit: 50000})
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("pool not emptied: pending %d, queued %d", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
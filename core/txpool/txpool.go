@@ -94,6 +94,19 @@ var (
 	// ErrOverdraft is returned if a transaction would cause the senders balance to go negative
 	// thus invalidating a potential large number of transactions.
 	ErrOverdraft = errors.New("transaction would cause overdraft")
+
+	// CHANGE(taiko): ErrAnchorSender is returned if a transaction is sent by the
+	// TaikoL2.anchor transaction sender, which is only allowed in the anchor
+	// transaction built by the L2 node.
+	ErrAnchorSender = errors.New("transaction sent by the anchor transaction sender")
+
+	// CHANGE(taiko): ErrTxGasLimitTooLow is returned if a transaction's gas limit
+	// is below the minimum gas limit of a transaction in a proposed txList.
+	ErrTxGasLimitTooLow = errors.New("transaction gas limit below protocol minimum")
+
+	// CHANGE(taiko): ErrTxListOversized is returned if a transaction alone exceeds
+	// the maximum size of a proposed txList's encoded bytes.
+	ErrTxListOversized = errors.New("transaction exceeds protocol txList size")
 )
 
 var (
@@ -175,6 +188,9 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	// CHANGE(taiko): protocol limits of the transactions which can be proposed.
+	ProtocolLimits ProtocolLimits
 }
 
 // DefaultConfig contains the default configurations for the transaction
@@ -230,6 +246,8 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	// CHANGE(taiko): sanitize the protocol limits.
+	conf.ProtocolLimits = conf.ProtocolLimits.sanitize()
 	return conf
 }
 
@@ -620,6 +638,12 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if pool.currentMaxGas < tx.Gas() {
 		return ErrGasLimit
 	}
+	// CHANGE(taiko): reject the transactions which can never be proposed.
+	if pool.chainconfig.Taiko {
+		if err := pool.validateProtocolLimits(tx); err != nil {
+			return err
+		}
+	}
 	// Sanity check for extremely large numbers
 	if tx.GasFeeCap().BitLen() > 256 {
 		return core.ErrFeeCapVeryHigh
@@ -636,6 +660,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if err != nil {
 		return ErrInvalidSender
 	}
+	// CHANGE(taiko): reject the transactions sent by the anchor transaction sender.
+	if pool.chainconfig.Taiko && from == pool.chainconfig.TaikoParams().AnchorSender {
+		return ErrAnchorSender
+	}
 	// Drop non-local transactions under our own minimal accepted gas price or tip
 	if !local && tx.GasTipCapIntCmp(pool.gasPrice) < 0 {
 		return ErrUnderpriced
@@ -1364,10 +1392,10 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	}
 	pool.currentState = statedb
 	pool.pendingNonces = newNoncer(statedb)
-	// CHANGE(taiko): current gas limit for transaction caps is always
-	// LibSharedConfig.blockMaxGasLimit defined in protocol.
+	// CHANGE(taiko): current gas limit for transaction caps is always the
+	// protocol's block max gas limit, LibSharedConfig.blockMaxGasLimit by default.
 	if pool.chainconfig.Taiko {
-		pool.currentMaxGas = 6000000 // LibSharedConfig.blockMaxGasLimit
+		pool.currentMaxGas = pool.config.ProtocolLimits.blockMaxGasLimit()
 	} else {
 		pool.currentMaxGas = newHead.GasLimit
 	}
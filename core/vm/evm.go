@@ -75,6 +75,10 @@ type BlockContext struct {
 	Difficulty  *big.Int       // Provides information for DIFFICULTY
 	BaseFee     *big.Int       // Provides information for BASEFEE
 	Random      *common.Hash   // Provides information for PREVRANDAO
+
+	// CHANGE(taiko): the block extra data, which carries the percentage of the
+	// basefee shared with the block proposer on Taiko networks since Ontake.
+	Extra []byte
 }
 
 // TxContext provides the EVM with information about a transaction.
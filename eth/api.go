@@ -117,6 +117,19 @@ func (api *MinerAPI) SetGasPrice(gasPrice hexutil.Big) bool {
 	return true
 }
 
+// CHANGE(taiko): SetTxPoolLimits sets the protocol limits of the transactions
+// accepted into the pool, dropping the pooled transactions beyond them. A zero
+// block gas limit or txList size falls back to the default one.
+func (api *MinerAPI) SetTxPoolLimits(minTxGasLimit hexutil.Uint64, blockMaxGasLimit hexutil.Uint64, maxBytesPerTxList hexutil.Uint64) bool {
+	limits := api.e.txPool.ProtocolLimits()
+	limits.MinTxGasLimit = uint64(minTxGasLimit)
+	limits.BlockMaxGasLimit = uint64(blockMaxGasLimit)
+	limits.MaxBytesPerTxList = uint64(maxBytesPerTxList)
+
+	api.e.txPool.SetProtocolLimits(limits)
+	return true
+}
+
 // SetGasLimit sets the gaslimit to target towards during mining.
 func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
 	api.e.Miner().SetGasCeil(uint64(gasLimit))
@@ -274,6 +287,12 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 		header = api.eth.blockchain.CurrentFinalBlock()
 	} else if blockNr == rpc.SafeBlockNumber {
 		header = api.eth.blockchain.CurrentSafeBlock()
+	} else if blockNr == rpc.PreconfirmedBlockNumber {
+		// CHANGE(taiko): the newest preconfirmed block, or the chain head if all
+		// the blocks are derived from L1.
+		if header = api.eth.blockchain.CurrentPreconfBlock(); header == nil {
+			header = api.eth.blockchain.CurrentBlock()
+		}
 	} else {
 		block := api.eth.blockchain.GetBlockByNumber(uint64(blockNr))
 		if block == nil {
@@ -358,6 +377,12 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 				header = api.eth.blockchain.CurrentFinalBlock()
 			} else if number == rpc.SafeBlockNumber {
 				header = api.eth.blockchain.CurrentSafeBlock()
+			} else if number == rpc.PreconfirmedBlockNumber {
+				// CHANGE(taiko): the newest preconfirmed block, or the chain head if
+				// all the blocks are derived from L1.
+				if header = api.eth.blockchain.CurrentPreconfBlock(); header == nil {
+					header = api.eth.blockchain.CurrentBlock()
+				}
 			} else {
 				block := api.eth.blockchain.GetBlockByNumber(uint64(number))
 				if block == nil {
@@ -75,7 +75,8 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 		return b.eth.blockchain.CurrentBlock(), nil
 	}
 	if number == rpc.FinalizedBlockNumber {
-		if !b.eth.Merger().TDDReached() {
+		// CHANGE(taiko): L2 finality is derived from L1, not from the beacon chain.
+		if !b.eth.Merger().TDDReached() && !b.eth.blockchain.Config().Taiko {
 			return nil, errors.New("'finalized' tag not supported on pre-merge network")
 		}
 		block := b.eth.blockchain.CurrentFinalBlock()
@@ -85,7 +86,8 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 		return nil, errors.New("finalized block not found")
 	}
 	if number == rpc.SafeBlockNumber {
-		if !b.eth.Merger().TDDReached() {
+		// CHANGE(taiko): L2 finality is derived from L1, not from the beacon chain.
+		if !b.eth.Merger().TDDReached() && !b.eth.blockchain.Config().Taiko {
 			return nil, errors.New("'safe' tag not supported on pre-merge network")
 		}
 		block := b.eth.blockchain.CurrentSafeBlock()
@@ -94,6 +96,14 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 		}
 		return nil, errors.New("safe block not found")
 	}
+	// CHANGE(taiko): the newest preconfirmed block, or the chain head if all the
+	// blocks are derived from L1.
+	if number == rpc.PreconfirmedBlockNumber {
+		if block := b.eth.blockchain.CurrentPreconfBlock(); block != nil {
+			return block, nil
+		}
+		return b.eth.blockchain.CurrentBlock(), nil
+	}
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
 }
 
@@ -130,17 +140,34 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 	if number == rpc.FinalizedBlockNumber {
-		if !b.eth.Merger().TDDReached() {
+		// CHANGE(taiko): L2 finality is derived from L1, not from the beacon chain.
+		if !b.eth.Merger().TDDReached() && !b.eth.blockchain.Config().Taiko {
 			return nil, errors.New("'finalized' tag not supported on pre-merge network")
 		}
 		header := b.eth.blockchain.CurrentFinalBlock()
+		if header == nil {
+			return nil, errors.New("finalized block not found")
+		}
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 	if number == rpc.SafeBlockNumber {
-		if !b.eth.Merger().TDDReached() {
+		// CHANGE(taiko): L2 finality is derived from L1, not from the beacon chain.
+		if !b.eth.Merger().TDDReached() && !b.eth.blockchain.Config().Taiko {
 			return nil, errors.New("'safe' tag not supported on pre-merge network")
 		}
 		header := b.eth.blockchain.CurrentSafeBlock()
+		if header == nil {
+			return nil, errors.New("safe block not found")
+		}
+		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
+	}
+	// CHANGE(taiko): the newest preconfirmed block, or the chain head if all the
+	// blocks are derived from L1.
+	if number == rpc.PreconfirmedBlockNumber {
+		header := b.eth.blockchain.CurrentPreconfBlock()
+		if header == nil {
+			header = b.eth.blockchain.CurrentBlock()
+		}
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 	return b.eth.blockchain.GetBlockByNumber(uint64(number)), nil
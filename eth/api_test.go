@@ -26,9 +26,15 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 )
 
@@ -223,3 +229,59 @@ func TestStorageRangeAt(t *testing.T) {
 		}
 	}
 }
+
+// CHANGE(taiko): test the preconfirmed block tag of the debug state dumps.
+func TestDumpPreconfirmedBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.GenerateKey()
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 2, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: new(big.Int).Mul(b.BaseFee(), common.Big2),
+		}))
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	api := NewDebugAPI(&Ethereum{blockchain: chain})
+
+	// Without preconfirmed blocks, the tag resolves to the chain head.
+	dump, err := api.DumpBlock(rpc.PreconfirmedBlockNumber)
+	if err != nil {
+		t.Fatalf("failed to dump preconfirmed state: %v", err)
+	}
+	if have, want := dump.Root, fmt.Sprintf("%x", blocks[1].Root()); have != want {
+		t.Fatalf("dumped state root mismatch: have %s, want %s", have, want)
+	}
+
+	// Otherwise to the newest preconfirmed block.
+	chain.SetPreconfirmed(blocks[0].Header())
+	dump, err = api.DumpBlock(rpc.PreconfirmedBlockNumber)
+	if err != nil {
+		t.Fatalf("failed to dump preconfirmed state: %v", err)
+	}
+	if have, want := dump.Root, fmt.Sprintf("%x", blocks[0].Root()); have != want {
+		t.Fatalf("dumped state root mismatch: have %s, want %s", have, want)
+	}
+
+	if _, err := api.AccountRange(rpc.BlockNumberOrHashWithNumber(rpc.PreconfirmedBlockNumber), nil, 0, true, true, true); err != nil {
+		t.Fatalf("failed to iterate preconfirmed state: %v", err)
+	}
+}
@@ -83,6 +83,8 @@ type Ethereum struct {
 
 	bloomRequests     chan chan *bloombits.Retrieval // Channel receiving bloom data retrieval requests
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
+	depositIndexer    *core.ChainIndexer             // CHANGE(taiko): Deposit indexer operating during block imports, if enabled
+	txLanes           *core.TxLanes                  // CHANGE(taiko): Priority sender lanes of the split transaction pool content
 	closeBloomHandler chan struct{}
 
 	APIBackend *EthAPIBackend
@@ -210,6 +212,16 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
 
+	// CHANGE(taiko): index the L1 -> L2 deposits by recipient if requested.
+	if config.TaikoDepositIndex && eth.blockchain.Config().Taiko {
+		eth.depositIndexer = core.NewTaikoDepositIndexer(chainDb, params.TaikoDepositIndexBlocks, params.TaikoDepositIndexConfirms)
+		eth.depositIndexer.Start(eth.blockchain)
+	}
+	// CHANGE(taiko): load the priority sender lanes, which can be changed at runtime.
+	if eth.txLanes, err = core.NewTxLanes(config.TaikoTxLanes); err != nil {
+		return nil, err
+	}
+
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
@@ -484,6 +496,8 @@ func (s *Ethereum) Synced() bool                       { return atomic.LoadUint3
 func (s *Ethereum) SetSynced()                         { atomic.StoreUint32(&s.handler.acceptTxs, 1) }
 func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruning }
 func (s *Ethereum) BloomIndexer() *core.ChainIndexer   { return s.bloomIndexer }
+func (s *Ethereum) DepositIndexer() *core.ChainIndexer { return s.depositIndexer } // CHANGE(taiko)
+func (s *Ethereum) TxLanes() *core.TxLanes             { return s.txLanes }        // CHANGE(taiko)
 func (s *Ethereum) Merger() *consensus.Merger          { return s.merger }
 func (s *Ethereum) SyncMode() downloader.SyncMode {
 	mode, _ := s.handler.chainSync.modeAndLocalHead()
@@ -534,6 +548,9 @@ func (s *Ethereum) Stop() error {
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
+	if s.depositIndexer != nil {
+		s.depositIndexer.Close()
+	}
 	close(s.closeBloomHandler)
 	s.txPool.Stop()
 	s.miner.Close()
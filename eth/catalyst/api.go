@@ -303,6 +303,9 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		// If the specified head matches with our local head, do nothing and keep
 		// generating the payload. It's a special corner case that a few slots are
 		// missing and we are requested to generate the payload in slot.
+	} else if isTaiko && api.eth.BlockChain().IsPreconfirmedAncestor(block.Header()) {
+		// CHANGE(taiko): the L1 derived chain is catching up with the preconfirmed
+		// blocks, keep them as the chain head.
 	} else if isTaiko { // CHANGE(taiko): reorg is allowed in L2.
 		if latestValid, err := api.eth.BlockChain().SetCanonical(block); err != nil {
 			return engine.ForkChoiceResponse{PayloadStatus: engine.PayloadStatusV1{Status: engine.INVALID, LatestValidHash: &latestValid}}, err
@@ -315,6 +318,11 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	}
 	api.eth.SetSynced()
 
+	// CHANGE(taiko): track the preconfirmed blocks ahead of the L1 derived chain.
+	if isTaiko {
+		api.updatePreconfHead(block)
+	}
+
 	// If the beacon client also advertised a finalized block, mark the local
 	// chain final and completely in PoS mode.
 	if update.FinalizedBlockHash != (common.Hash{}) {
@@ -356,7 +364,7 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 			// No need to check payloadAttribute here, because all its fields are
 			// marked as required.
 
-			block, err := api.eth.Miner().SealBlockWith(
+			block, skipped, fees, err := api.eth.Miner().SealBlockWith(
 				update.HeadBlockHash,
 				payloadAttributes.Timestamp,
 				payloadAttributes.BlockMetadata,
@@ -384,10 +392,13 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 				return valid(nil), engine.InvalidPayloadAttributes.With(err)
 			}
 
-			payload.SetFullBlock(block, common.Big0)
+			payload.SetFullBlock(block, fees)
 
 			api.localBlocks.put(id, payload)
 
+			// Write the transactions skipped while sealing the block.
+			rawdb.WriteSkippedTransactions(api.eth.ChainDb(), block.Hash(), skipped)
+
 			// L1Origin **MUST NOT** be nil, it's a required field in PayloadAttributesV1.
 			l1Origin := payloadAttributes.L1Origin
 
@@ -396,8 +407,10 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 
 			// Write L1Origin.
 			rawdb.WriteL1Origin(api.eth.ChainDb(), l1Origin.BlockID, l1Origin)
-			// Write the head L1Origin.
-			rawdb.WriteHeadL1Origin(api.eth.ChainDb(), l1Origin.BlockID)
+			// Write the head L1Origin, which only tracks the L1 derived blocks.
+			if !l1Origin.IsPreconfirmed {
+				rawdb.WriteHeadL1Origin(api.eth.ChainDb(), l1Origin.BlockID)
+			}
 
 			return valid(&id), nil
 		}
@@ -476,6 +489,14 @@ func (api *ConsensusAPI) getPayload(payloadID engine.PayloadID) (*engine.Executi
 	if data == nil {
 		return nil, engine.UnknownPayload
 	}
+	// CHANGE(taiko): attach the transactions skipped while sealing the block.
+	if api.eth.BlockChain().Config().Taiko {
+		skipped, err := rawdb.ReadSkippedTransactions(api.eth.ChainDb(), data.ExecutionPayload.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		data.ExecutionPayload.SkippedTransactions = skipped
+	}
 	return data, nil
 }
 
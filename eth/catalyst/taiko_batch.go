@@ -0,0 +1,161 @@
+package catalyst
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// InsertTaikoBlocksV1 builds a L2 block for each of the given payload attributes,
+// in order, each block on top of the previous one, the first one on top of the
+// given parent block. The built blocks are inserted, their L1Origins are written
+// in a single batch, and the last one becomes the chain head.
+//
+// A status is returned for each attempted block, the blocks following a block
+// which can't be built are not attempted, while the blocks preceding it are kept.
+// If the built blocks can't be set as the chain head, the statuses are returned
+// along with the error, and the L1Origins they overwrote are restored. Should the
+// node crash before the chain head is switched, the L1Origins pointing to blocks
+// which didn't become canonical are removed when the chain is repaired at startup.
+// This replaces the `engine_forkchoiceUpdated`, `engine_newPayload` and
+// `engine_forkchoiceUpdated` round-trips per block when many L2 blocks are derived
+// from a single L1 block.
+func (api *ConsensusAPI) InsertTaikoBlocksV1(parentHash common.Hash, attributes []*engine.PayloadAttributes) ([]engine.TaikoBlockStatusV1, error) {
+	if !api.eth.BlockChain().Config().Taiko {
+		return nil, errors.New("only supported on Taiko chains")
+	}
+	for i, attr := range attributes {
+		if attr == nil || attr.BlockMetadata == nil || attr.L1Origin == nil || attr.BaseFeePerGas == nil {
+			return nil, engine.InvalidPayloadAttributes.With(fmt.Errorf("incomplete payload attributes %d", i))
+		}
+		if err := api.verifyPayloadAttributes(attr); err != nil {
+			return nil, engine.InvalidPayloadAttributes.With(fmt.Errorf("payload attributes %d: %w", i, err))
+		}
+	}
+
+	api.forkchoiceLock.Lock()
+	defer api.forkchoiceLock.Unlock()
+
+	api.lastForkchoiceLock.Lock()
+	api.lastForkchoiceUpdate = time.Now()
+	api.lastForkchoiceLock.Unlock()
+
+	log.Trace("Engine API request received", "method", "InsertTaikoBlocks", "parent", parentHash, "blocks", len(attributes))
+
+	bc := api.eth.BlockChain()
+	if bc.GetBlockByHash(parentHash) == nil {
+		return nil, engine.InvalidForkChoiceState.With(fmt.Errorf("unknown parent block %x", parentHash))
+	}
+
+	headL1Origin, err := rawdb.ReadHeadL1Origin(api.eth.ChainDb())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		statuses    = make([]engine.TaikoBlockStatusV1, 0, len(attributes))
+		batch       = api.eth.ChainDb().NewBatch()
+		written     []*rawdb.L1Origin
+		overwritten []*rawdb.L1Origin
+		head        *types.Block
+	)
+	for _, attr := range attributes {
+		block, skipped, _, err := api.eth.Miner().SealBlockWith(
+			parentHash,
+			attr.Timestamp,
+			attr.BlockMetadata,
+			attr.BaseFeePerGas,
+			attr.Withdrawals,
+			types.CalcWithdrawalsRootTaiko(attr.Withdrawals),
+		)
+		if err == nil {
+			err = bc.InsertBlockWithoutSetHead(block)
+		}
+		if err != nil {
+			log.Warn("Failed to insert Taiko block", "blockID", attr.L1Origin.BlockID, "err", err)
+			errMsg := err.Error()
+			statuses = append(statuses, engine.TaikoBlockStatusV1{Status: engine.INVALID, ValidationError: &errMsg})
+			break
+		}
+
+		hash := block.Hash()
+		statuses = append(statuses, engine.TaikoBlockStatusV1{
+			Status:              engine.VALID,
+			BlockHash:           &hash,
+			SkippedTransactions: skipped,
+		})
+
+		rawdb.WriteSkippedTransactions(batch, hash, skipped)
+
+		// Set the block hash before inserting the L1Origin into database.
+		l1Origin := *attr.L1Origin
+		l1Origin.L2BlockHash = hash
+		prev, err := rawdb.ReadL1Origin(api.eth.ChainDb(), l1Origin.BlockID)
+		if err != nil {
+			return statuses, err
+		}
+		written, overwritten = append(written, &l1Origin), append(overwritten, prev)
+		rawdb.WriteL1OriginBatch(api.eth.ChainDb(), batch, l1Origin.BlockID, &l1Origin)
+		// Write the head L1Origin, which only tracks the L1 derived blocks.
+		if !l1Origin.IsPreconfirmed {
+			rawdb.WriteHeadL1Origin(batch, l1Origin.BlockID)
+		}
+
+		parentHash, head = hash, block
+	}
+	if head == nil {
+		return statuses, nil
+	}
+
+	// The L1Origins are written before switching the chain head, so that a new head
+	// is never left without them.
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to write L1Origins", "err", err)
+	}
+	// The L1 derived chain may be catching up with the preconfirmed blocks, keep
+	// them as the chain head then. If the blocks can't become canonical, they are
+	// kept as side ones, and the overwritten L1Origins are restored, so that the
+	// L1Origins keep pointing to the canonical blocks.
+	if !bc.IsPreconfirmedAncestor(head.Header()) {
+		if _, err := bc.SetCanonical(head); err != nil {
+			log.Warn("Failed to set the Taiko blocks as canonical", "number", head.Number(), "hash", head.Hash(), "err", err)
+			restoreL1Origins(api.eth.ChainDb(), written, overwritten, headL1Origin)
+			return statuses, fmt.Errorf("failed to set the chain head %x: %w", head.Hash(), err)
+		}
+	}
+	api.eth.SetSynced()
+	api.updatePreconfHead(head)
+
+	log.Info("Inserted Taiko blocks", "blocks", len(statuses), "number", head.Number(), "hash", head.Hash())
+
+	return statuses, nil
+}
+
+// restoreL1Origins reverts the given written L1Origins to the ones they overwrote,
+// nil if there was none, and the head L1Origin pointer to the given one.
+func restoreL1Origins(db ethdb.KeyValueStore, written []*rawdb.L1Origin, overwritten []*rawdb.L1Origin, headL1Origin *big.Int) {
+	batch := db.NewBatch()
+	for i, l1Origin := range written {
+		if prev := overwritten[i]; prev != nil {
+			rawdb.WriteL1OriginBatch(db, batch, prev.BlockID, prev)
+		} else {
+			rawdb.DeleteL1Origin(batch, l1Origin)
+		}
+	}
+	if headL1Origin != nil {
+		rawdb.WriteHeadL1Origin(batch, headL1Origin)
+	} else {
+		rawdb.DeleteHeadL1Origin(batch)
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to restore L1Origins", "err", err)
+	}
+}
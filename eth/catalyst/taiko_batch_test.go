@@ -0,0 +1,207 @@
+package catalyst
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// newTaikoPayloadAttributes creates the payload attributes of the L2 block with
+// the given ID, derived from the L1 block at the given height, its txList holding
+// an anchor transaction followed by a transfer.
+func newTaikoPayloadAttributes(t *testing.T, config *params.ChainConfig, parent *types.Header, blockID int64, l1Height int64) *engine.PayloadAttributes {
+	var (
+		signer     = types.LatestSigner(config)
+		l2Contract = config.TaikoParams().L2Contract
+		nonce      = uint64(blockID - 1)
+		timestamp  = parent.Time + uint64(blockID)*12
+	)
+	txList, err := rlp.EncodeToBytes(types.Transactions{
+		types.MustSignNewTx(goldenTouchKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      params.AnchorGasLimit,
+			To:       &l2Contract,
+			Data:     append(common.CopyBytes(taiko.AnchorSelector), make([]byte, 4*32)...),
+		}),
+		types.MustSignNewTx(testKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+			Gas:      params.TxGas,
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+		}),
+	})
+	require.Nil(t, err)
+
+	return &engine.PayloadAttributes{
+		Timestamp:             timestamp,
+		SuggestedFeeRecipient: common.Address{0xbb},
+		Withdrawals:           make([]*types.Withdrawal, 0),
+		BaseFeePerGas:         big.NewInt(params.InitialBaseFee),
+		BlockMetadata: &engine.BlockMetadata{
+			Beneficiary:    common.Address{0xbb},
+			GasLimit:       params.GenesisGasLimit,
+			Timestamp:      timestamp,
+			TxList:         txList,
+			HighestBlockID: big.NewInt(blockID),
+		},
+		L1Origin: &rawdb.L1Origin{
+			BlockID:       big.NewInt(blockID),
+			L1BlockHeight: big.NewInt(l1Height),
+			L1BlockHash:   common.BigToHash(big.NewInt(l1Height)),
+		},
+	}
+}
+
+func TestInsertTaikoBlocks(t *testing.T) {
+	config := *params.TaikoChainConfig
+	genesis := &core.Genesis{
+		Config:     &config,
+		Alloc:      core.GenesisAlloc{testAddr: {Balance: testBalance}},
+		Timestamp:  9000,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: common.Big0,
+	}
+	n, ethservice := startTaikoEthService(t, genesis, nil)
+	defer n.Close()
+
+	var (
+		api    = NewConsensusAPI(ethservice)
+		chain  = ethservice.BlockChain()
+		db     = ethservice.ChainDb()
+		parent = chain.Genesis().Header()
+	)
+
+	// All the L2 blocks derived from a L1 block are inserted in a single call.
+	attributes := []*engine.PayloadAttributes{
+		newTaikoPayloadAttributes(t, &config, parent, 1, 100),
+		newTaikoPayloadAttributes(t, &config, parent, 2, 100),
+		newTaikoPayloadAttributes(t, &config, parent, 3, 100),
+	}
+	statuses, err := api.InsertTaikoBlocksV1(parent.Hash(), attributes)
+	require.Nil(t, err)
+	require.Len(t, statuses, 3)
+	for i, status := range statuses {
+		require.Equal(t, engine.VALID, status.Status)
+		require.Empty(t, status.SkippedTransactions)
+
+		block := chain.GetBlockByNumber(uint64(i + 1))
+		require.Equal(t, block.Hash(), *status.BlockHash)
+		require.Len(t, block.Transactions(), 2)
+
+		l1Origin, err := rawdb.ReadL1Origin(db, big.NewInt(int64(i+1)))
+		require.Nil(t, err)
+		require.Equal(t, block.Hash(), l1Origin.L2BlockHash)
+	}
+	require.Equal(t, *statuses[2].BlockHash, chain.CurrentBlock().Hash())
+
+	headL1Origin, err := rawdb.ReadHeadL1Origin(db)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(3), headL1Origin)
+
+	l1Origins, err := rawdb.ReadL1OriginsByL1BlockHeight(db, big.NewInt(100))
+	require.Nil(t, err)
+	require.Len(t, l1Origins, 3)
+
+	// The blocks following a block which can't be built are not attempted, the
+	// preceding ones are kept.
+	attributes = []*engine.PayloadAttributes{
+		newTaikoPayloadAttributes(t, &config, parent, 4, 101),
+		newTaikoPayloadAttributes(t, &config, parent, 5, 101),
+		newTaikoPayloadAttributes(t, &config, parent, 6, 101),
+	}
+	attributes[1].BlockMetadata.TxList = []byte{0x01}
+
+	statuses, err = api.InsertTaikoBlocksV1(*statuses[2].BlockHash, attributes)
+	require.Nil(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, engine.VALID, statuses[0].Status)
+	require.Equal(t, engine.INVALID, statuses[1].Status)
+	require.Nil(t, statuses[1].BlockHash)
+	require.NotNil(t, statuses[1].ValidationError)
+
+	require.Equal(t, *statuses[0].BlockHash, chain.CurrentBlock().Hash())
+	headL1Origin, err = rawdb.ReadHeadL1Origin(db)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(4), headL1Origin)
+
+	l1Origin, err := rawdb.ReadL1Origin(db, big.NewInt(5))
+	require.Nil(t, err)
+	require.Nil(t, l1Origin)
+
+	// Incomplete payload attributes are rejected before building any block.
+	attributes = []*engine.PayloadAttributes{
+		newTaikoPayloadAttributes(t, &config, parent, 5, 102),
+		{Timestamp: parent.Time + 72},
+	}
+	_, err = api.InsertTaikoBlocksV1(chain.CurrentBlock().Hash(), attributes)
+	require.NotNil(t, err)
+	require.Equal(t, uint64(4), chain.CurrentBlock().Number.Uint64())
+
+	// Unknown parent blocks are rejected.
+	_, err = api.InsertTaikoBlocksV1(common.Hash{0x01}, attributes[:1])
+	require.NotNil(t, err)
+}
+
+func TestRestoreL1Origins(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	prev := &rawdb.L1Origin{
+		BlockID:       big.NewInt(1),
+		L2BlockHash:   common.Hash{0x01},
+		L1BlockHeight: big.NewInt(100),
+		L1BlockHash:   common.Hash{0xaa},
+	}
+	rawdb.WriteL1Origin(db, prev.BlockID, prev)
+	rawdb.WriteHeadL1Origin(db, prev.BlockID)
+
+	// A batch overwrites block #1 and adds block #2, but the blocks don't become
+	// canonical.
+	written := []*rawdb.L1Origin{
+		{BlockID: big.NewInt(1), L2BlockHash: common.Hash{0x02}, L1BlockHeight: big.NewInt(101), L1BlockHash: common.Hash{0xbb}},
+		{BlockID: big.NewInt(2), L2BlockHash: common.Hash{0x03}, L1BlockHeight: big.NewInt(101), L1BlockHash: common.Hash{0xbb}},
+	}
+	overwritten := []*rawdb.L1Origin{prev, nil}
+	for _, l1Origin := range written {
+		rawdb.WriteL1Origin(db, l1Origin.BlockID, l1Origin)
+	}
+	rawdb.WriteHeadL1Origin(db, big.NewInt(2))
+
+	restoreL1Origins(db, written, overwritten, prev.BlockID)
+
+	l1Origin, err := rawdb.ReadL1Origin(db, big.NewInt(1))
+	require.Nil(t, err)
+	require.Equal(t, prev, l1Origin)
+
+	l1Origin, err = rawdb.ReadL1Origin(db, big.NewInt(2))
+	require.Nil(t, err)
+	require.Nil(t, l1Origin)
+
+	headL1Origin, err := rawdb.ReadHeadL1Origin(db)
+	require.Nil(t, err)
+	require.Equal(t, prev.BlockID, headL1Origin)
+
+	l1Origins, err := rawdb.ReadL1OriginsByL1BlockHeight(db, big.NewInt(101))
+	require.Nil(t, err)
+	require.Empty(t, l1Origins)
+
+	l1Origins, err = rawdb.ReadL1OriginsByL1BlockHash(db, prev.L1BlockHash)
+	require.Nil(t, err)
+	require.Equal(t, []*rawdb.L1Origin{prev}, l1Origins)
+
+	// Without a previous head L1Origin, the pointer is removed.
+	restoreL1Origins(db, nil, nil, nil)
+	headL1Origin, err = rawdb.ReadHeadL1Origin(db)
+	require.Nil(t, err)
+	require.Nil(t, headL1Origin)
+}
@@ -0,0 +1,28 @@
+package catalyst
+
+import (
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// updatePreconfHead updates the preconfirmed head of the chain after a forkchoice
+// update to the given block. If the new chain head was built ahead of its L1
+// inclusion, it becomes the newest preconfirmed block, otherwise the given block
+// is derived from L1, and confirms the preconfirmed blocks up to it.
+func (api *ConsensusAPI) updatePreconfHead(block *types.Block) {
+	bc := api.eth.BlockChain()
+
+	l1Origin, err := rawdb.ReadL1Origin(api.eth.ChainDb(), block.Number())
+	if err != nil {
+		log.Error("Failed to read L1Origin", "number", block.Number(), "err", err)
+		return
+	}
+	if l1Origin != nil && l1Origin.IsPreconfirmed && l1Origin.L2BlockHash == block.Hash() {
+		if bc.CurrentBlock().Hash() == block.Hash() {
+			bc.SetPreconfirmed(block.Header())
+		}
+		return
+	}
+	bc.ConfirmPreconfirmed(block.Header())
+}
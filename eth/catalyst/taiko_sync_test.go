@@ -0,0 +1,196 @@
+package catalyst
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+var goldenTouchKey, _ = crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
+
+// generateTaikoChain creates a Taiko chain of n blocks, each block starting with
+// an anchor transaction, and every other block carrying deposits.
+func generateTaikoChain(n int) (*core.Genesis, []*types.Block) {
+	config := *params.TaikoChainConfig
+	genesis := &core.Genesis{
+		Config:     &config,
+		Alloc:      core.GenesisAlloc{testAddr: {Balance: testBalance}},
+		ExtraData:  []byte("test genesis"),
+		Timestamp:  9000,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: common.Big0,
+	}
+	var (
+		signer     = types.LatestSigner(&config)
+		l2Contract = config.TaikoParams().L2Contract
+	)
+	generate := func(i int, g *core.BlockGen) {
+		g.OffsetTime(5)
+		g.SetExtra([]byte("test_taiko"))
+		g.SetDifficulty(common.Big0)
+
+		g.AddTx(types.MustSignNewTx(goldenTouchKey, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      params.AnchorGasLimit,
+			To:       &l2Contract,
+			Data:     append(common.CopyBytes(taiko.AnchorSelector), make([]byte, 4*32)...),
+		}))
+		g.AddTx(types.MustSignNewTx(testKey, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+			Gas:      params.TxGas,
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+		}))
+		if i%2 == 0 {
+			g.AddWithdrawal(&types.Withdrawal{Address: common.Address{0xbb}, Amount: uint64(i + 1)})
+		}
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, taiko.New(nil), n, generate)
+	return genesis, blocks
+}
+
+// startTaikoEthService creates a full node instance for testing, with the state
+// snapshot enabled to serve snap sync.
+func startTaikoEthService(t *testing.T, genesis *core.Genesis, blocks []*types.Block) (*node.Node, *eth.Ethereum) {
+	t.Helper()
+
+	n, err := node.New(&node.Config{
+		P2P: p2p.Config{
+			ListenAddr:  "0.0.0.0:0",
+			NoDiscovery: true,
+			MaxPeers:    25,
+		}})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+
+	ethcfg := &ethconfig.Config{Genesis: genesis, SyncMode: downloader.FullSync, TrieTimeout: time.Minute, TrieDirtyCache: 256, TrieCleanCache: 256, SnapshotCache: 256}
+	ethservice, err := eth.New(n, ethcfg)
+	if err != nil {
+		t.Fatal("can't create eth service:", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	if _, err := ethservice.BlockChain().InsertChain(blocks); err != nil {
+		n.Close()
+		t.Fatal("can't import test blocks:", err)
+	}
+	ethservice.SetSynced()
+	return n, ethservice
+}
+
+func TestTaikoSync(t *testing.T) {
+	t.Run("full", func(t *testing.T) { testTaikoSync(t, downloader.FullSync) })
+	t.Run("snap", func(t *testing.T) { testTaikoSync(t, downloader.SnapSync) })
+}
+
+// testTaikoSync syncs a Taiko chain between two nodes over P2P, checking that
+// the deposits of the downloaded block bodies are hashed the Taiko way.
+func testTaikoSync(t *testing.T, mode downloader.SyncMode) {
+	genesis, blocks := generateTaikoChain(128)
+	head := blocks[len(blocks)-1]
+
+	nodeA, _ := startTaikoEthService(t, genesis, blocks)
+	defer nodeA.Close()
+	nodeB, ethserviceB := startTaikoEthService(t, genesis, nil)
+	defer nodeB.Close()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for nodeA.Server().NodeInfo().Ports.Listener == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("listener timed out")
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	nodeB.Server().AddPeer(nodeA.Server().Self())
+	deadline = time.Now().Add(10 * time.Second)
+	for nodeB.Server().PeerCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("peering timed out")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The L2 chain is synced the post-merge way, from the head given by the
+	// consensus client.
+	require.Nil(t, ethserviceB.Downloader().BeaconSync(mode, head.Header(), nil))
+
+	chain := ethserviceB.BlockChain()
+	deadline = time.Now().Add(30 * time.Second)
+	for chain.CurrentBlock().Hash() != head.Hash() {
+		if time.Now().After(deadline) {
+			t.Fatalf("sync timed out, head #%d, want #%d", chain.CurrentBlock().Number, head.NumberU64())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	for _, block := range blocks {
+		synced := chain.GetBlockByHash(block.Hash())
+		require.NotNil(t, synced)
+		require.Equal(t, block.Withdrawals().Len(), synced.Withdrawals().Len())
+		require.Equal(t, *block.Header().WithdrawalsHash, *synced.Header().WithdrawalsHash)
+	}
+}
+
+// TestTaikoFeeMetricsNewPayload checks that the fee metrics account the blocks fed
+// by a driver, which are inserted by newPayload without setting the head, and
+// only become canonical with the following forkchoiceUpdated.
+func TestTaikoFeeMetricsNewPayload(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	genesis, blocks := generateTaikoChain(3)
+	n, ethservice := startTaikoEthService(t, genesis, nil)
+	defer n.Close()
+
+	var (
+		api     = NewConsensusAPI(ethservice)
+		chain   = ethservice.BlockChain()
+		counter = metrics.DefaultRegistry.Get("chain/taiko/fees/treasurybasefee").(metrics.Counter)
+		start   = counter.Count()
+		want    int64
+	)
+	for _, block := range blocks {
+		status, err := api.NewPayloadV2(*engine.BlockToExecutableData(block, nil).ExecutionPayload)
+		require.Nil(t, err)
+		require.Equal(t, engine.VALID, status.Status)
+		require.Equal(t, start+want, counter.Count())
+
+		_, err = api.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{HeadBlockHash: block.Hash()}, nil)
+		require.Nil(t, err)
+		require.Equal(t, block.Hash(), chain.CurrentBlock().Hash())
+
+		stats, err := chain.GetTaikoFeeStats(block.Hash(), block.NumberU64())
+		require.Nil(t, err)
+		require.Positive(t, stats.TreasuryBaseFee.Sign())
+		want += new(big.Int).Div(stats.TreasuryBaseFee, big.NewInt(params.GWei)).Int64()
+		require.Equal(t, start+want, counter.Count())
+	}
+
+	// The fees of the blocks reorged out of the canonical chain are subtracted.
+	_, err := api.ForkchoiceUpdatedV2(engine.ForkchoiceStateV1{HeadBlockHash: blocks[0].Hash()}, nil)
+	require.Nil(t, err)
+	require.Equal(t, blocks[0].Hash(), chain.CurrentBlock().Hash())
+
+	stats, err := chain.GetTaikoFeeStats(blocks[0].Hash(), blocks[0].NumberU64())
+	require.Nil(t, err)
+	require.Equal(t, start+new(big.Int).Div(stats.TreasuryBaseFee, big.NewInt(params.GWei)).Int64(), counter.Count())
+}
@@ -22,7 +22,6 @@ package downloader
 import (
 	"errors"
 	"fmt"
-	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 const (
@@ -141,6 +141,8 @@ type queue struct {
 	resultCache *resultStore       // Downloaded but not yet delivered fetch results
 	resultSize  common.StorageSize // Approximate size of a block (exponential moving average)
 
+	config *params.ChainConfig // CHANGE(taiko): chain config deciding how the downloaded withdrawals are hashed
+
 	lock   *sync.RWMutex
 	active *sync.Cond
 	closed bool
@@ -793,17 +795,10 @@ func (q *queue) DeliverBodies(id string, txLists [][]*types.Transaction, txListH
 			if withdrawalLists[index] == nil {
 				return errInvalidBody
 			}
-			// CHANGE(taiko): change the empty withdrawalsRoot hash to EmptyCodeHash
-			if withdrawalListHashes[index] == types.EmptyRootHash {
-				if os.Getenv("TAIKO_TEST") == "" {
-					withdrawalListHashes[index] = common.HexToHash("0x569e75fc77c1a856f6daaf9e69d8a9566ca34aa47f9133711ce065a571af0cfd")
-				}
-			} else {
-				if os.Getenv("TAIKO_TEST") == "" {
-					withdrawalListHashes[index] = types.CalcWithdrawalsRootTaiko(withdrawalLists[index])
-				}
+			// CHANGE(taiko): Taiko blocks hash their withdrawals with a custom hasher.
+			if q.config != nil && q.config.Taiko {
+				withdrawalListHashes[index] = types.CalcWithdrawalsRootTaiko(withdrawalLists[index])
 			}
-
 			if withdrawalListHashes[index] != *header.WithdrawalsHash {
 				return errInvalidBody
 			}
@@ -208,13 +208,21 @@ type Config struct {
 
 	// OverrideShanghai (TODO: remove after the fork)
 	OverrideShanghai *uint64 `toml:",omitempty"`
+
+	// CHANGE(taiko): TaikoDepositIndex enables the index of the L1 -> L2 deposits
+	// by recipient.
+	TaikoDepositIndex bool `toml:",omitempty"`
+
+	// CHANGE(taiko): TaikoTxLanes is the configuration of the priority sender
+	// lanes used when splitting the transaction pool content.
+	TaikoTxLanes *core.TxLanesConfig `toml:",omitempty"`
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain configuration.
 func CreateConsensusEngine(stack *node.Node, ethashConfig *ethash.Config, cliqueConfig *params.CliqueConfig, notify []string, noverify bool, db ethdb.Database, isTaiko bool) consensus.Engine {
 	// CHANGE(taiko): use Taiko consensus engine when the --taiko flag is set.
 	if isTaiko {
-		return taiko.New()
+		return taiko.New(db)
 	}
 
 	// If proof-of-authority is requested, set it up
@@ -61,6 +61,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Checkpoint              *params.TrustedCheckpoint      `toml:",omitempty"`
 		CheckpointOracle        *params.CheckpointOracleConfig `toml:",omitempty"`
 		OverrideShanghai        *uint64                        `toml:",omitempty"`
+		TaikoDepositIndex       bool                           `toml:",omitempty"`
+		TaikoTxLanes            *core.TxLanesConfig            `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -106,6 +108,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Checkpoint = c.Checkpoint
 	enc.CheckpointOracle = c.CheckpointOracle
 	enc.OverrideShanghai = c.OverrideShanghai
+	enc.TaikoDepositIndex = c.TaikoDepositIndex
+	enc.TaikoTxLanes = c.TaikoTxLanes
 	return &enc, nil
 }
 
@@ -155,6 +159,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Checkpoint              *params.TrustedCheckpoint      `toml:",omitempty"`
 		CheckpointOracle        *params.CheckpointOracleConfig `toml:",omitempty"`
 		OverrideShanghai        *uint64                        `toml:",omitempty"`
+		TaikoDepositIndex       *bool                          `toml:",omitempty"`
+		TaikoTxLanes            *core.TxLanesConfig            `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -289,5 +295,11 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.OverrideShanghai != nil {
 		c.OverrideShanghai = dec.OverrideShanghai
 	}
+	if dec.TaikoDepositIndex != nil {
+		c.TaikoDepositIndex = *dec.TaikoDepositIndex
+	}
+	if dec.TaikoTxLanes != nil {
+		c.TaikoTxLanes = dec.TaikoTxLanes
+	}
 	return nil
 }
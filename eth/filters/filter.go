@@ -142,6 +142,11 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 			if hdr == nil {
 				return 0, errors.New("safe header not found")
 			}
+		case rpc.PreconfirmedBlockNumber.Int64():
+			hdr, _ = f.sys.backend.HeaderByNumber(ctx, rpc.PreconfirmedBlockNumber)
+			if hdr == nil {
+				return 0, errors.New("preconfirmed header not found")
+			}
 		default:
 			return number, nil
 		}
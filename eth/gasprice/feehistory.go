@@ -183,6 +183,8 @@ func (oracle *Oracle) resolveBlockRange(ctx context.Context, reqEnd rpc.BlockNum
 			resolved = headBlock
 		case rpc.SafeBlockNumber:
 			resolved, err = oracle.backend.HeaderByNumber(ctx, rpc.SafeBlockNumber)
+		case rpc.PreconfirmedBlockNumber: // CHANGE(taiko)
+			resolved, err = oracle.backend.HeaderByNumber(ctx, rpc.PreconfirmedBlockNumber)
 		case rpc.FinalizedBlockNumber:
 			resolved, err = oracle.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
 		case rpc.EarliestBlockNumber:
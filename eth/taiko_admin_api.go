@@ -0,0 +1,38 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TaikoAdminAPI is the collection of Taiko specific administrative APIs, served
+// under the "admin_" RPC namespace.
+type TaikoAdminAPI struct {
+	eth *Ethereum
+}
+
+// NewTaikoAdminAPI creates a new TaikoAdminAPI instance.
+func NewTaikoAdminAPI(eth *Ethereum) *TaikoAdminAPI {
+	return &TaikoAdminAPI{eth: eth}
+}
+
+// TaikoTxLanes returns the current priority sender lanes configuration, or nil
+// if no lanes are configured.
+func (api *TaikoAdminAPI) TaikoTxLanes() *core.TxLanesConfig {
+	return api.eth.TxLanes().Config()
+}
+
+// SetTaikoTxLanes replaces the priority sender lanes configuration used by the
+// following `taiko_txPoolContent` calls, a null configuration removes all the
+// lanes.
+func (api *TaikoAdminAPI) SetTaikoTxLanes(config *core.TxLanesConfig) (bool, error) {
+	if err := api.eth.TxLanes().SetConfig(config); err != nil {
+		return false, err
+	}
+	if config == nil {
+		log.Info("Removed Taiko priority sender lanes")
+	} else {
+		log.Info("Updated Taiko priority sender lanes", "lanes", len(config.Lanes), "policy", config.Policy)
+	}
+	return true, nil
+}
@@ -1,9 +1,13 @@
 package eth
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -23,6 +27,19 @@ func NewTaikoAPIBackend(eth *Ethereum) *TaikoAPIBackend {
 	}
 }
 
+// TaikoAuthAPIBackend handles the l2 node related RPC calls which modify the
+// chain, they are only served by the authenticated endpoint of the node.
+type TaikoAuthAPIBackend struct {
+	eth *Ethereum
+}
+
+// NewTaikoAuthAPIBackend creates a new TaikoAuthAPIBackend instance.
+func NewTaikoAuthAPIBackend(eth *Ethereum) *TaikoAuthAPIBackend {
+	return &TaikoAuthAPIBackend{
+		eth: eth,
+	}
+}
+
 // HeadL1Origin returns the latest L2 block's corresponding L1 origin.
 func (s *TaikoAPIBackend) HeadL1Origin() (*rawdb.L1Origin, error) {
 	blockID, err := rawdb.ReadHeadL1Origin(s.eth.ChainDb())
@@ -60,6 +77,285 @@ func (s *TaikoAPIBackend) L1OriginByID(blockID *math.HexOrDecimal256) (*rawdb.L1
 	return l1Origin, nil
 }
 
+// L1OriginsByL1BlockHash returns the L1 origins of all L2 blocks derived from
+// the given L1 block hash.
+func (s *TaikoAPIBackend) L1OriginsByL1BlockHash(l1BlockHash common.Hash) ([]*rawdb.L1Origin, error) {
+	l1Origins, err := rawdb.ReadL1OriginsByL1BlockHash(s.eth.ChainDb(), l1BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l1Origins) == 0 {
+		return nil, ethereum.NotFound
+	}
+
+	return l1Origins, nil
+}
+
+// L1OriginsByL1BlockHeight returns the L1 origins of all L2 blocks derived from
+// the L1 block(s) at the given height.
+func (s *TaikoAPIBackend) L1OriginsByL1BlockHeight(l1BlockHeight *math.HexOrDecimal256) ([]*rawdb.L1Origin, error) {
+	l1Origins, err := rawdb.ReadL1OriginsByL1BlockHeight(s.eth.ChainDb(), (*big.Int)(l1BlockHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l1Origins) == 0 {
+		return nil, ethereum.NotFound
+	}
+
+	return l1Origins, nil
+}
+
+// SkippedTransactions returns the transactions in the given canonical L2 block's
+// txList, which were skipped while sealing the block.
+func (s *TaikoAPIBackend) SkippedTransactions(blockID *math.HexOrDecimal256) ([]*rawdb.SkippedTransaction, error) {
+	blockHash := rawdb.ReadCanonicalHash(s.eth.ChainDb(), (*big.Int)(blockID).Uint64())
+	if blockHash == (common.Hash{}) {
+		return nil, ethereum.NotFound
+	}
+
+	skipped, err := rawdb.ReadSkippedTransactions(s.eth.ChainDb(), blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if skipped == nil {
+		skipped = make([]*rawdb.SkippedTransaction, 0)
+	}
+
+	return skipped, nil
+}
+
+const (
+	// defaultDepositsPageSize is the number of deposits returned by a
+	// taiko_getDepositsByAddress call if no limit is given.
+	defaultDepositsPageSize = 100
+
+	// maxDepositsPageSize is the maximum number of deposits returned by a
+	// taiko_getDepositsByAddress call.
+	maxDepositsPageSize = 1000
+)
+
+// GetDepositsByAddress returns the L1 -> L2 ETH deposits credited to the given
+// address in the canonical chain, in ascending deposit index order, starting from
+// the given deposit index. The next page starts after the index of the last
+// returned deposit. The deposit index has to be enabled with --taiko.depositindex,
+// the deposits of the newest blocks are only returned once their index section
+// is complete.
+func (s *TaikoAPIBackend) GetDepositsByAddress(address common.Address, start *hexutil.Uint64, limit *hexutil.Uint64) ([]*rawdb.TaikoDeposit, error) {
+	if s.eth.DepositIndexer() == nil {
+		return nil, errors.New("deposit index disabled, restart the node with --taiko.depositindex")
+	}
+	var (
+		from = uint64(0)
+		size = defaultDepositsPageSize
+	)
+	if start != nil {
+		from = uint64(*start)
+	}
+	if limit != nil {
+		if *limit == 0 || *limit > maxDepositsPageSize {
+			return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", *limit, maxDepositsPageSize)
+		}
+		size = int(*limit)
+	}
+
+	deposits, err := rawdb.ReadTaikoDepositsByAddress(s.eth.ChainDb(), address, from, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if deposits == nil {
+		deposits = make([]*rawdb.TaikoDeposit, 0)
+	}
+
+	return deposits, nil
+}
+
+// maxFeeStatsRange is the maximum number of blocks covered by a taiko_feeStats call.
+const maxFeeStatsRange = 1000
+
+// FeeStats returns the fee accounting of the canonical L2 blocks within the given
+// inclusive range: the priority fees and the shared base fees credited to the
+// block proposers, the base fees credited to the treasury and the gas used by
+// the anchor transactions.
+func (s *TaikoAPIBackend) FeeStats(from *math.HexOrDecimal256, to *math.HexOrDecimal256) ([]*rawdb.TaikoFeeStats, error) {
+	if from == nil || to == nil {
+		return nil, errors.New("missing block range")
+	}
+	var (
+		start = (*big.Int)(from).Uint64()
+		end   = (*big.Int)(to).Uint64()
+		chain = s.eth.BlockChain()
+	)
+	if start > end {
+		return nil, fmt.Errorf("invalid range, from %d is above to %d", start, end)
+	}
+	if end-start >= maxFeeStatsRange {
+		return nil, fmt.Errorf("invalid range, at most %d blocks are allowed", maxFeeStatsRange)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); end > head {
+		end = head
+	}
+
+	stats := make([]*rawdb.TaikoFeeStats, 0)
+	for number := start; number <= end; number++ {
+		hash := rawdb.ReadCanonicalHash(s.eth.ChainDb(), number)
+		if hash == (common.Hash{}) {
+			return nil, ethereum.NotFound
+		}
+		block, err := chain.GetTaikoFeeStats(hash, number)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, ethereum.NotFound
+		}
+		stats = append(stats, block)
+	}
+
+	return stats, nil
+}
+
+// GetBlockWitness re-executes the given canonical L2 block against its parent state,
+// and returns the witness of all the state it accessed, which is enough to execute
+// the block statelessly. The parent state must still be available. Re-executing
+// blocks is expensive, so it is only served by the authenticated endpoint.
+func (s *TaikoAuthAPIBackend) GetBlockWitness(blockID *math.HexOrDecimal256) (*core.Witness, error) {
+	block := s.eth.BlockChain().GetBlockByNumber((*big.Int)(blockID).Uint64())
+	if block == nil {
+		return nil, ethereum.NotFound
+	}
+	if block.NumberU64() == 0 {
+		return nil, fmt.Errorf("genesis block has no witness")
+	}
+
+	return s.eth.BlockChain().BlockWitness(block)
+}
+
+// SetL1Finality reports the height of the newest finalized L1 block, the safe and
+// finalized L2 blocks are then derived from the L1Origins of the canonical chain,
+// so that the "safe" and "finalized" block tags can be used on L2.
+func (s *TaikoAuthAPIBackend) SetL1Finality(l1Height *math.HexOrDecimal256) error {
+	if l1Height == nil {
+		return fmt.Errorf("missing L1 finalized height")
+	}
+
+	return s.eth.BlockChain().SetL1Finality((*big.Int)(l1Height).Uint64())
+}
+
+// RewindToL1Ancestor rewinds the L2 chain after a L1 reorg, to the newest L2 block
+// whose L1 origin is still canonical on L1. The given L1 block is the latest one
+// known to be canonical after the reorg, its height can be omitted if at least one
+// L2 block was derived from it. All L1Origin records above the new L2 head are
+// removed, and the head L1Origin pointer is moved to the new L2 head.
+func (s *TaikoAuthAPIBackend) RewindToL1Ancestor(l1Hash common.Hash, l1Height *math.HexOrDecimal256) (*rawdb.L1Origin, error) {
+	db := s.eth.ChainDb()
+
+	ancestorHeight := (*big.Int)(l1Height)
+	if ancestorHeight == nil {
+		l1Origins, err := rawdb.ReadL1OriginsByL1BlockHash(db, l1Hash)
+		if err != nil {
+			return nil, err
+		}
+		if len(l1Origins) == 0 {
+			return nil, fmt.Errorf("no L2 block derived from L1 block %s, its height is required", l1Hash)
+		}
+		if ancestorHeight = l1Origins[0].L1BlockHeight; ancestorHeight == nil {
+			return nil, fmt.Errorf("no L1 height recorded for L1 block %s, its height is required", l1Hash)
+		}
+	}
+
+	headID, err := rawdb.ReadHeadL1Origin(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if headID == nil {
+		return nil, ethereum.NotFound
+	}
+
+	// Walk backwards from the head L1Origin, until reaching a L2 block whose L1 origin
+	// is still canonical, or the genesis. The L2 blocks without L1Origin record are
+	// skipped, they can't tell whether their L1 origin was reorged out.
+	var (
+		newHead = new(big.Int).Set(headID)
+		target  *rawdb.L1Origin
+		stale   []*rawdb.L1Origin
+		missing int
+	)
+	for ; newHead.Sign() > 0; newHead.Sub(newHead, common.Big1) {
+		l1Origin, err := rawdb.ReadL1Origin(db, newHead)
+		if err != nil {
+			return nil, err
+		}
+		if l1Origin == nil {
+			missing++
+			continue
+		}
+		// Preconfirmed and legacy records may carry no L1 origin, they can't tell
+		// whether it was reorged out either, but are removed along with the stale
+		// ones above the new head.
+		if l1Origin.IsPreconfirmed || l1Origin.L1BlockHeight == nil {
+			missing++
+			stale = append(stale, l1Origin)
+			continue
+		}
+		if isCanonicalL1Origin(l1Origin, l1Hash, ancestorHeight) {
+			target = l1Origin
+			break
+		}
+		stale = append(stale, l1Origin)
+	}
+
+	if len(stale) == 0 {
+		return target, nil
+	}
+	// Don't rewind to the genesis, if some L1Origin records are missing (e.g. the
+	// blocks were synced from L2 peers), any of those blocks may still be valid.
+	if target == nil && missing > 0 {
+		return nil, fmt.Errorf("no canonical L1 origin found, %d L1Origin records are missing or incomplete", missing)
+	}
+
+	// Rewinding the chain removes the L1Origin records above the new head and moves
+	// the head L1Origin pointer as part of SetHead, otherwise they're removed here.
+	if s.eth.BlockChain().CurrentBlock().Number.Cmp(newHead) > 0 {
+		if err := s.eth.BlockChain().SetHead(newHead.Uint64()); err != nil {
+			return nil, err
+		}
+	} else {
+		rawdb.RepairL1Origins(db, stale, newHead.Uint64())
+	}
+
+	log.Info(
+		"Rewound L2 chain to L1 ancestor",
+		"l1Hash", l1Hash,
+		"l1Height", ancestorHeight,
+		"head", newHead,
+		"removedL1Origins", len(stale),
+	)
+
+	return target, nil
+}
+
+// isCanonicalL1Origin checks whether the given L1 origin is still canonical on L1,
+// given the latest L1 block known to be canonical after a L1 reorg. A L1 origin
+// without L1 height is never known to be canonical.
+func isCanonicalL1Origin(l1Origin *rawdb.L1Origin, ancestorHash common.Hash, ancestorHeight *big.Int) bool {
+	if l1Origin.L1BlockHeight == nil {
+		return false
+	}
+	switch l1Origin.L1BlockHeight.Cmp(ancestorHeight) {
+	case -1:
+		return true
+	case 0:
+		return l1Origin.L1BlockHash == ancestorHash
+	default:
+		return false
+	}
+}
+
 // TxPoolContent retrieves the transaction pool content with the given upper limits.
 func (s *TaikoAPIBackend) TxPoolContent(
 	maxTransactionsPerBlock uint64,
@@ -68,7 +364,44 @@ func (s *TaikoAPIBackend) TxPoolContent(
 	minTxGasLimit uint64,
 	locals []string,
 ) ([]types.Transactions, error) {
-	pending := s.eth.TxPool().Pending(false)
+	preBuiltTxLists, err := s.TxPoolContentWithOptions(
+		maxTransactionsPerBlock,
+		blockMaxGasLimit,
+		maxBytesPerTxList,
+		minTxGasLimit,
+		locals,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	txLists := make([]types.Transactions, 0, len(preBuiltTxLists))
+	for _, preBuiltTxList := range preBuiltTxLists {
+		txLists = append(txLists, preBuiltTxList.TxList)
+	}
+
+	return txLists, nil
+}
+
+// TxPoolContentWithOptions retrieves the transaction pool content with the given
+// upper limits and options, along with the size and the execution information of
+// each transactions list.
+func (s *TaikoAPIBackend) TxPoolContentWithOptions(
+	maxTransactionsPerBlock uint64,
+	blockMaxGasLimit uint64,
+	maxBytesPerTxList uint64,
+	minTxGasLimit uint64,
+	locals []string,
+	opts *core.TxPoolContentOptions,
+) ([]*core.PreBuiltTxList, error) {
+	if opts == nil {
+		opts = new(core.TxPoolContentOptions)
+	}
+	var (
+		pending = s.eth.TxPool().Pending(false)
+		lanes   = s.eth.TxLanes().Config()
+	)
 
 	log.Debug(
 		"Fetching L2 pending transactions finished",
@@ -78,8 +411,22 @@ func (s *TaikoAPIBackend) TxPoolContent(
 		"maxBytesPerTxList", maxBytesPerTxList,
 		"minTxGasLimit", minTxGasLimit,
 		"locals", locals,
+		"compression", opts.Compression,
+		"simulate", opts.Simulate,
+		"lanes", lanes != nil,
 	)
 
+	var simulator core.TxSimulator
+	if opts.Simulate {
+		txSimulator, err := s.eth.Miner().NewTxSimulator()
+		if err != nil {
+			return nil, err
+		}
+		defer txSimulator.Discard()
+
+		simulator = txSimulator
+	}
+
 	contentSplitter, err := core.NewPoolContentSplitter(
 		s.eth.BlockChain().Config().ChainID,
 		maxTransactionsPerBlock,
@@ -87,6 +434,9 @@ func (s *TaikoAPIBackend) TxPoolContent(
 		maxBytesPerTxList,
 		minTxGasLimit,
 		locals,
+		opts.Compression,
+		simulator,
+		lanes,
 	)
 	if err != nil {
 		return nil, err
@@ -94,16 +444,16 @@ func (s *TaikoAPIBackend) TxPoolContent(
 
 	var (
 		txsCount = 0
-		txLists  []types.Transactions
+		txLists  []*core.PreBuiltTxList
 	)
 	for _, splittedTxs := range contentSplitter.Split(pending) {
-		if txsCount+splittedTxs.Len() < int(maxTransactionsPerBlock) {
+		if txsCount+splittedTxs.TxList.Len() < int(maxTransactionsPerBlock) {
 			txLists = append(txLists, splittedTxs)
-			txsCount += splittedTxs.Len()
+			txsCount += splittedTxs.TxList.Len()
 			continue
 		}
 
-		txLists = append(txLists, splittedTxs[0:(int(maxTransactionsPerBlock)-txsCount)])
+		txLists = append(txLists, contentSplitter.Truncate(splittedTxs, int(maxTransactionsPerBlock)-txsCount))
 		break
 	}
 
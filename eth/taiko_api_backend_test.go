@@ -0,0 +1,31 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCanonicalL1Origin(t *testing.T) {
+	var (
+		ancestorHash   = common.Hash{1}
+		ancestorHeight = big.NewInt(10)
+	)
+	for i, tt := range []struct {
+		l1Origin  *rawdb.L1Origin
+		canonical bool
+	}{
+		{&rawdb.L1Origin{L1BlockHeight: big.NewInt(9), L1BlockHash: common.Hash{2}}, true},
+		{&rawdb.L1Origin{L1BlockHeight: big.NewInt(10), L1BlockHash: ancestorHash}, true},
+		{&rawdb.L1Origin{L1BlockHeight: big.NewInt(10), L1BlockHash: common.Hash{2}}, false},
+		{&rawdb.L1Origin{L1BlockHeight: big.NewInt(11), L1BlockHash: ancestorHash}, false},
+		// Preconfirmed and legacy records may have no L1 height.
+		{&rawdb.L1Origin{L1BlockHash: ancestorHash}, false},
+		{&rawdb.L1Origin{IsPreconfirmed: true}, false},
+	} {
+		require.Equal(t, tt.canonical, isCanonicalL1Origin(tt.l1Origin, ancestorHash, ancestorHeight), "test %d", i)
+	}
+}
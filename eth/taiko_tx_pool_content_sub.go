@@ -0,0 +1,203 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultTxPoolContentDebounce is the default time to batch the transaction pool
+	// changes for, before pushing new transactions lists to a subscriber.
+	defaultTxPoolContentDebounce = 200 * time.Millisecond
+
+	// txPoolContentMaxDebounces is the number of debounce intervals a push can be
+	// delayed for at most, while the transaction pool keeps changing.
+	txPoolContentMaxDebounces = 5
+
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
+)
+
+// TaikoSubscriptionAPI handles the subscriptions under the "taiko_" RPC namespace,
+// it's a separate service since a RPC method and a subscription can't share the
+// same Go method name.
+type TaikoSubscriptionAPI struct {
+	backend *TaikoAPIBackend
+}
+
+// NewTaikoSubscriptionAPI creates a new TaikoSubscriptionAPI instance.
+func NewTaikoSubscriptionAPI(backend *TaikoAPIBackend) *TaikoSubscriptionAPI {
+	return &TaikoSubscriptionAPI{
+		backend: backend,
+	}
+}
+
+// TxPoolContent creates a subscription which pushes freshly split transactions
+// lists, each time the pending transactions in pool change, either because new
+// transactions arrive or because a new L2 block is inserted. The lists are pushed
+// once the pool didn't change for the debounce interval, or at the latest five
+// intervals after the first change. A set of lists is only pushed when it differs
+// from the previous one and the first list is filled with at least `MinTxsPerList`
+// transactions.
+func (api *TaikoSubscriptionAPI) TxPoolContent(ctx context.Context, limits core.TxPoolContentLimits) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	if limits.MaxTransactionsPerBlock == 0 {
+		return nil, errors.New("maxTransactionsPerBlock is required")
+	}
+	// Validate the remaining limits once, rather than failing in the event loop.
+	if _, err := core.NewPoolContentSplitter(
+		api.backend.eth.BlockChain().Config().ChainID,
+		limits.MaxTransactionsPerBlock,
+		limits.BlockMaxGasLimit,
+		limits.MaxBytesPerTxList,
+		limits.MinTxGasLimit,
+		limits.Locals,
+		limits.Compression,
+		nil,
+		nil,
+	); err != nil {
+		return nil, err
+	}
+
+	debounce := defaultTxPoolContentDebounce
+	if limits.Debounce != 0 {
+		debounce = time.Duration(limits.Debounce) * time.Millisecond
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		var (
+			txsCh   = make(chan core.NewTxsEvent, txChanSize)
+			txsSub  = api.backend.eth.TxPool().SubscribeNewTxsEvent(txsCh)
+			headCh  = make(chan core.ChainHeadEvent, chainHeadChanSize)
+			headSub = api.backend.eth.BlockChain().SubscribeChainHeadEvent(headCh)
+
+			// The subscription counts as a change, so the current pool content is
+			// pushed right away if it satisfies the limits.
+			debouncer = newTxPoolContentDebouncer(debounce)
+			timer     = time.NewTimer(debouncer.changed(time.Now()))
+			last      common.Hash
+		)
+		defer func() {
+			txsSub.Unsubscribe()
+			headSub.Unsubscribe()
+			timer.Stop()
+		}()
+
+		reset := func() {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(debouncer.changed(time.Now()))
+		}
+
+		for {
+			select {
+			case <-txsCh:
+				reset()
+			case <-headCh:
+				reset()
+			case <-timer.C:
+				debouncer.pushed()
+
+				txLists, err := api.backend.TxPoolContentWithOptions(
+					limits.MaxTransactionsPerBlock,
+					limits.BlockMaxGasLimit,
+					limits.MaxBytesPerTxList,
+					limits.MinTxGasLimit,
+					limits.Locals,
+					&limits.TxPoolContentOptions,
+				)
+				if err != nil {
+					log.Warn("Failed to split L2 pending transactions", "err", err)
+					continue
+				}
+				if len(txLists) == 0 || uint64(txLists[0].TxList.Len()) < limits.MinTxsPerList {
+					continue
+				}
+				if digest := txListsDigest(txLists); digest != last {
+					last = digest
+					notifier.Notify(rpcSub.ID, txLists)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-txsSub.Err():
+				return
+			case <-headSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// txPoolContentDebouncer delays the pushes of a transactions lists subscription
+// until the transaction pool changes settle: each change postpones the push by the
+// debounce interval, but the push is never delayed for more than a few intervals
+// after the first change since the previous push.
+type txPoolContentDebouncer struct {
+	interval time.Duration
+	maxWait  time.Duration
+	first    time.Time // First change since the previous push, zero if none
+}
+
+func newTxPoolContentDebouncer(interval time.Duration) *txPoolContentDebouncer {
+	return &txPoolContentDebouncer{
+		interval: interval,
+		maxWait:  txPoolContentMaxDebounces * interval,
+	}
+}
+
+// changed records a change of the transaction pool at the given time, and returns
+// the delay until the push.
+func (d *txPoolContentDebouncer) changed(now time.Time) time.Duration {
+	if d.first.IsZero() {
+		d.first = now
+	}
+	delay := d.interval
+	if deadline := d.first.Add(d.maxWait); now.Add(delay).After(deadline) {
+		delay = deadline.Sub(now)
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return delay
+}
+
+// pushed records that the pool content was pushed.
+func (d *txPoolContentDebouncer) pushed() {
+	d.first = time.Time{}
+}
+
+// txListsDigest returns the hash of all transaction hashes in the given lists,
+// so that two sets of lists can be cheaply compared.
+func txListsDigest(txLists []*core.PreBuiltTxList) common.Hash {
+	var hashes []byte
+	for _, txList := range txLists {
+		for _, tx := range txList.TxList {
+			hashes = append(hashes, tx.Hash().Bytes()...)
+		}
+		// Separate the lists, so that moving a transaction to another list counts
+		// as a change.
+		hashes = append(hashes, common.Hash{}.Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
@@ -0,0 +1,59 @@
+package eth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// debouncePushes replays the given pool change times against a debouncer, and
+// returns the times the pool content is pushed at.
+func debouncePushes(d *txPoolContentDebouncer, changes []time.Time) []time.Time {
+	var (
+		pushes   []time.Time
+		deadline time.Time
+	)
+	for _, change := range changes {
+		if !deadline.IsZero() && !deadline.After(change) {
+			pushes = append(pushes, deadline)
+			d.pushed()
+		}
+		deadline = change.Add(d.changed(change))
+	}
+	return append(pushes, deadline)
+}
+
+func TestTxPoolContentDebouncer(t *testing.T) {
+	var (
+		interval = 100 * time.Millisecond
+		start    = time.Unix(0, 0)
+	)
+	changesEvery := func(period time.Duration, n int) []time.Time {
+		changes := make([]time.Time, n)
+		for i := range changes {
+			changes[i] = start.Add(time.Duration(i) * period)
+		}
+		return changes
+	}
+
+	// Changes faster than the interval are pushed once, an interval after they stop.
+	pushes := debouncePushes(newTxPoolContentDebouncer(interval), changesEvery(20*time.Millisecond, 10))
+	require.Equal(t, []time.Time{start.Add(180*time.Millisecond + interval)}, pushes)
+
+	// Changes slower than the interval are pushed each.
+	pushes = debouncePushes(newTxPoolContentDebouncer(interval), changesEvery(150*time.Millisecond, 3))
+	require.Equal(t, []time.Time{
+		start.Add(interval),
+		start.Add(150*time.Millisecond + interval),
+		start.Add(300*time.Millisecond + interval),
+	}, pushes)
+
+	// A steady stream of changes is pushed at least every max wait.
+	pushes = debouncePushes(newTxPoolContentDebouncer(interval), changesEvery(20*time.Millisecond, 60))
+	require.Equal(t, []time.Time{
+		start.Add(txPoolContentMaxDebounces * interval),
+		start.Add(2 * txPoolContentMaxDebounces * interval),
+		start.Add(1180*time.Millisecond + interval),
+	}, pushes)
+}
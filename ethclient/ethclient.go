@@ -583,6 +583,10 @@ func toBlockNumArg(number *big.Int) string {
 	if number.Cmp(safe) == 0 {
 		return "safe"
 	}
+	preconfirmed := big.NewInt(int64(rpc.PreconfirmedBlockNumber))
+	if number.Cmp(preconfirmed) == 0 {
+		return "preconfirmed"
+	}
 	return hexutil.EncodeBig(number)
 }
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 )
 
@@ -29,3 +31,103 @@ func (ec *Client) L1OriginByID(ctx context.Context, blockID *big.Int) (*rawdb.L1
 
 	return res, nil
 }
+
+// L1OriginsByL1BlockHash returns the L1 origins of all L2 blocks derived from
+// the given L1 block hash.
+func (ec *Client) L1OriginsByL1BlockHash(ctx context.Context, l1BlockHash common.Hash) ([]*rawdb.L1Origin, error) {
+	var res []*rawdb.L1Origin
+
+	if err := ec.c.CallContext(ctx, &res, "taiko_l1OriginsByL1BlockHash", l1BlockHash); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// L1OriginsByL1BlockHeight returns the L1 origins of all L2 blocks derived from
+// the L1 block(s) at the given height.
+func (ec *Client) L1OriginsByL1BlockHeight(ctx context.Context, l1BlockHeight *big.Int) ([]*rawdb.L1Origin, error) {
+	var res []*rawdb.L1Origin
+
+	if err := ec.c.CallContext(ctx, &res, "taiko_l1OriginsByL1BlockHeight", hexutil.EncodeBig(l1BlockHeight)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RewindToL1Ancestor rewinds the L2 chain to the newest L2 block whose L1 origin is
+// still canonical on L1, and returns the new head L1 origin. The L1 ancestor height
+// is optional if at least one L2 block was derived from that L1 block. Only served
+// by the authenticated endpoint.
+func (ec *Client) RewindToL1Ancestor(ctx context.Context, l1Hash common.Hash, l1Height *big.Int) (*rawdb.L1Origin, error) {
+	var (
+		res  *rawdb.L1Origin
+		args = []interface{}{l1Hash}
+	)
+	if l1Height != nil {
+		args = append(args, hexutil.EncodeBig(l1Height))
+	}
+
+	if err := ec.c.CallContext(ctx, &res, "taikoAuth_rewindToL1Ancestor", args...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SkippedTransactions returns the transactions in the given L2 block's txList,
+// which were skipped while sealing the block.
+func (ec *Client) SkippedTransactions(ctx context.Context, blockID *big.Int) ([]*rawdb.SkippedTransaction, error) {
+	var res []*rawdb.SkippedTransaction
+
+	if err := ec.c.CallContext(ctx, &res, "taiko_skippedTransactions", hexutil.EncodeBig(blockID)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// BlockWitness returns the witness of all the state accessed by the given L2 block,
+// which is enough to re-execute it with core.ExecuteBlockWithWitness. Only served
+// by the authenticated endpoint.
+func (ec *Client) BlockWitness(ctx context.Context, blockID *big.Int) (*core.Witness, error) {
+	var res *core.Witness
+
+	if err := ec.c.CallContext(ctx, &res, "taikoAuth_getBlockWitness", hexutil.EncodeBig(blockID)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetL1Finality reports the height of the newest finalized L1 block, from which
+// the node derives its safe and finalized L2 blocks. Only served by the
+// authenticated endpoint.
+func (ec *Client) SetL1Finality(ctx context.Context, l1Height *big.Int) error {
+	return ec.c.CallContext(ctx, nil, "taikoAuth_setL1Finality", hexutil.EncodeBig(l1Height))
+}
+
+// DepositsByAddress returns at most limit L1 -> L2 ETH deposits credited to the
+// given address, starting from the given deposit index.
+func (ec *Client) DepositsByAddress(ctx context.Context, address common.Address, start uint64, limit uint64) ([]*rawdb.TaikoDeposit, error) {
+	var res []*rawdb.TaikoDeposit
+
+	if err := ec.c.CallContext(ctx, &res, "taiko_getDepositsByAddress", address, hexutil.Uint64(start), hexutil.Uint64(limit)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// FeeStats returns the fee accounting of the canonical L2 blocks within the given
+// inclusive range.
+func (ec *Client) FeeStats(ctx context.Context, from *big.Int, to *big.Int) ([]*rawdb.TaikoFeeStats, error) {
+	var res []*rawdb.TaikoFeeStats
+
+	if err := ec.c.CallContext(ctx, &res, "taiko_feeStats", hexutil.EncodeBig(from), hexutil.EncodeBig(to)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
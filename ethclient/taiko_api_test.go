@@ -5,10 +5,12 @@ import (
 	"crypto/rand"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth"
@@ -36,13 +38,26 @@ func newTaikoAPITestClient(t *testing.T) (*Client, []*types.Block, ethdb.Databas
 	ethservice, err := eth.New(n, config)
 	require.Nil(t, err)
 
+	taikoAPIBackend := eth.NewTaikoAPIBackend(ethservice)
 	n.RegisterAPIs([]rpc.API{
 		{
 			Namespace: "taiko",
 			Version:   params.VersionWithMeta,
-			Service:   eth.NewTaikoAPIBackend(ethservice),
+			Service:   taikoAPIBackend,
 			Public:    true,
 		},
+		{
+			Namespace: "taiko",
+			Version:   params.VersionWithMeta,
+			Service:   eth.NewTaikoSubscriptionAPI(taikoAPIBackend),
+			Public:    true,
+		},
+		{
+			Namespace:     "taikoAuth",
+			Version:       params.VersionWithMeta,
+			Service:       eth.NewTaikoAuthAPIBackend(ethservice),
+			Authenticated: true,
+		},
 	})
 
 	// Start node
@@ -108,6 +123,255 @@ func TestL1OriginByID(t *testing.T) {
 	require.Equal(t, testL1Origin, l1OriginFound)
 }
 
+func TestL1OriginsByL1Block(t *testing.T) {
+	ec, blocks, db := newTaikoAPITestClient(t)
+
+	testL1Origin := &rawdb.L1Origin{
+		BlockID:       big.NewInt(1),
+		L2BlockHash:   blocks[1].Hash(),
+		L1BlockHeight: big.NewInt(10),
+		L1BlockHash:   randomHash(),
+	}
+
+	l1OriginsFound, err := ec.L1OriginsByL1BlockHash(context.Background(), testL1Origin.L1BlockHash)
+	require.Equal(t, ethereum.NotFound.Error(), err.Error())
+	require.Nil(t, l1OriginsFound)
+
+	rawdb.WriteL1Origin(db, testL1Origin.BlockID, testL1Origin)
+
+	l1OriginsFound, err = ec.L1OriginsByL1BlockHash(context.Background(), testL1Origin.L1BlockHash)
+	require.Nil(t, err)
+	require.Equal(t, []*rawdb.L1Origin{testL1Origin}, l1OriginsFound)
+
+	l1OriginsFound, err = ec.L1OriginsByL1BlockHeight(context.Background(), testL1Origin.L1BlockHeight)
+	require.Nil(t, err)
+	require.Equal(t, []*rawdb.L1Origin{testL1Origin}, l1OriginsFound)
+}
+
+func TestRewindToL1Ancestor(t *testing.T) {
+	ec, blocks, db := newTaikoAPITestClient(t)
+
+	var l1Origins []*rawdb.L1Origin
+	for i := 1; i < len(blocks); i++ {
+		l1Origin := &rawdb.L1Origin{
+			BlockID:       blocks[i].Number(),
+			L2BlockHash:   blocks[i].Hash(),
+			L1BlockHeight: big.NewInt(int64(10 + i)),
+			L1BlockHash:   randomHash(),
+		}
+		rawdb.WriteL1Origin(db, l1Origin.BlockID, l1Origin)
+		rawdb.WriteHeadL1Origin(db, l1Origin.BlockID)
+		l1Origins = append(l1Origins, l1Origin)
+	}
+
+	// Unknown L1 ancestor without height.
+	_, err := ec.RewindToL1Ancestor(context.Background(), randomHash(), nil)
+	require.NotNil(t, err)
+
+	headL1Origin, err := ec.RewindToL1Ancestor(context.Background(), l1Origins[0].L1BlockHash, nil)
+	require.Nil(t, err)
+	require.Equal(t, l1Origins[0], headL1Origin)
+
+	head, err := ec.BlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, l1Origins[0].BlockID.Uint64(), head)
+
+	headL1Origin, err = ec.HeadL1Origin(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, l1Origins[0], headL1Origin)
+
+	_, err = ec.L1OriginByID(context.Background(), l1Origins[1].BlockID)
+	require.Equal(t, ethereum.NotFound.Error(), err.Error())
+}
+
+func TestRewindToL1AncestorMissingL1Origins(t *testing.T) {
+	ec, blocks, db := newTaikoAPITestClient(t)
+
+	l1Origin := &rawdb.L1Origin{
+		BlockID:       blocks[2].Number(),
+		L2BlockHash:   blocks[2].Hash(),
+		L1BlockHeight: big.NewInt(12),
+		L1BlockHash:   randomHash(),
+	}
+	rawdb.WriteL1Origin(db, l1Origin.BlockID, l1Origin)
+	rawdb.WriteHeadL1Origin(db, l1Origin.BlockID)
+
+	// The L1Origin of block #1 is missing, the chain isn't rewound to the genesis.
+	_, err := ec.RewindToL1Ancestor(context.Background(), randomHash(), big.NewInt(11))
+	require.NotNil(t, err)
+
+	head, err := ec.BlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, blocks[2].NumberU64(), head)
+
+	headL1Origin, err := ec.HeadL1Origin(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, l1Origin, headL1Origin)
+
+	// Missing L1Origin records are skipped while looking for the L1 ancestor.
+	ancestor := &rawdb.L1Origin{
+		BlockID:       blocks[1].Number(),
+		L2BlockHash:   blocks[1].Hash(),
+		L1BlockHeight: big.NewInt(11),
+		L1BlockHash:   randomHash(),
+	}
+	rawdb.WriteL1Origin(db, ancestor.BlockID, ancestor)
+	rawdb.DeleteL1Origin(db, l1Origin)
+
+	headL1Origin, err = ec.RewindToL1Ancestor(context.Background(), ancestor.L1BlockHash, nil)
+	require.Nil(t, err)
+	require.Equal(t, ancestor, headL1Origin)
+
+	head, err = ec.BlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, blocks[2].NumberU64(), head)
+}
+
+func TestRewindToL1AncestorPreconfirmed(t *testing.T) {
+	ec, blocks, db := newTaikoAPITestClient(t)
+
+	ancestor := &rawdb.L1Origin{
+		BlockID:       blocks[1].Number(),
+		L2BlockHash:   blocks[1].Hash(),
+		L1BlockHeight: big.NewInt(11),
+		L1BlockHash:   randomHash(),
+	}
+	preconfirmed := &rawdb.L1Origin{
+		BlockID:        blocks[2].Number(),
+		L2BlockHash:    blocks[2].Hash(),
+		IsPreconfirmed: true,
+	}
+	rawdb.WriteL1Origin(db, preconfirmed.BlockID, preconfirmed)
+	rawdb.WriteHeadL1Origin(db, preconfirmed.BlockID)
+
+	// A preconfirmed record can't tell whether its L1 origin is canonical.
+	_, err := ec.RewindToL1Ancestor(context.Background(), randomHash(), big.NewInt(11))
+	require.NotNil(t, err)
+
+	rawdb.WriteL1Origin(db, ancestor.BlockID, ancestor)
+	headL1Origin, err := ec.RewindToL1Ancestor(context.Background(), ancestor.L1BlockHash, nil)
+	require.Nil(t, err)
+	require.Equal(t, ancestor, headL1Origin)
+
+	head, err := ec.BlockNumber(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, blocks[1].NumberU64(), head)
+
+	_, err = ec.L1OriginByID(context.Background(), preconfirmed.BlockID)
+	require.Equal(t, ethereum.NotFound.Error(), err.Error())
+}
+
+func TestSkippedTransactions(t *testing.T) {
+	ec, blocks, db := newTaikoAPITestClient(t)
+
+	_, err := ec.SkippedTransactions(context.Background(), big.NewInt(int64(len(blocks))))
+	require.Equal(t, ethereum.NotFound.Error(), err.Error())
+
+	skipped, err := ec.SkippedTransactions(context.Background(), blocks[1].Number())
+	require.Nil(t, err)
+	require.Empty(t, skipped)
+
+	testSkipped := []*rawdb.SkippedTransaction{
+		{Index: 1, Hash: randomHash(), Reason: "nonce too low"},
+	}
+	rawdb.WriteSkippedTransactions(db, blocks[1].Hash(), testSkipped)
+
+	skipped, err = ec.SkippedTransactions(context.Background(), blocks[1].Number())
+	require.Nil(t, err)
+	require.Equal(t, testSkipped, skipped)
+}
+
+func TestBlockWitness(t *testing.T) {
+	ec, blocks, _ := newTaikoAPITestClient(t)
+
+	_, err := ec.BlockWitness(context.Background(), big.NewInt(int64(len(blocks))))
+	require.Equal(t, ethereum.NotFound.Error(), err.Error())
+
+	// Block #2 contains the test transactions.
+	block := blocks[2]
+	witness, err := ec.BlockWitness(context.Background(), block.Number())
+	require.Nil(t, err)
+	require.Equal(t, block.ParentHash(), witness.Headers[0].Hash())
+	require.NotEmpty(t, witness.State)
+
+	receipts, err := core.ExecuteBlockWithWitness(genesis.Config, ethash.NewFaker(), block, witness)
+	require.Nil(t, err)
+	require.Len(t, receipts, len(block.Transactions()))
+}
+
+func TestFeeStats(t *testing.T) {
+	ec, blocks, _ := newTaikoAPITestClient(t)
+
+	_, err := ec.FeeStats(context.Background(), common.Big2, common.Big1)
+	require.NotNil(t, err)
+	_, err = ec.FeeStats(context.Background(), common.Big0, big.NewInt(1000))
+	require.NotNil(t, err)
+
+	// The range is capped to the chain head.
+	stats, err := ec.FeeStats(context.Background(), common.Big0, big.NewInt(int64(len(blocks))))
+	require.Nil(t, err)
+	require.Len(t, stats, len(blocks))
+	for i, block := range blocks {
+		require.Equal(t, block.Hash(), stats[i].BlockHash)
+		require.Equal(t, block.NumberU64(), stats[i].BlockNumber)
+	}
+
+	// Block #2 contains the test transactions, which pay the base fee.
+	require.Positive(t, stats[2].TreasuryBaseFee.Sign())
+}
+
+func TestSubscribeTxPoolContent(t *testing.T) {
+	ec, _, _ := newTaikoAPITestClient(t)
+
+	var (
+		ch     = make(chan []*core.PreBuiltTxList, 1)
+		limits = core.TxPoolContentLimits{
+			MaxTransactionsPerBlock: 10,
+			BlockMaxGasLimit:        params.TxGas * 10,
+			MaxBytesPerTxList:       params.MaxCodeSize,
+			MinTxGasLimit:           params.TxGas - 1,
+			MinTxsPerList:           2,
+			Debounce:                10,
+		}
+	)
+	sub, err := ec.c.Subscribe(context.Background(), "taiko", ch, "txPoolContent", limits)
+	require.Nil(t, err)
+	defer sub.Unsubscribe()
+
+	var txs types.Transactions
+	for nonce := uint64(2); nonce < 4; nonce++ {
+		tx := types.MustSignNewTx(testKey, types.LatestSigner(genesis.Config), &types.LegacyTx{
+			Nonce:    nonce,
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      params.TxGas,
+			To:       &common.Address{2},
+		})
+		require.Nil(t, ec.SendTransaction(context.Background(), tx))
+		txs = append(txs, tx)
+	}
+
+	// The lists are only pushed once the first list is filled with two transactions,
+	// so the first push contains both of them, even if the pool content is split
+	// in between.
+	select {
+	case txLists := <-ch:
+		require.Len(t, txLists, 1)
+		require.Len(t, txLists[0].TxList, len(txs))
+		for i, tx := range txs {
+			require.Equal(t, tx.Hash(), txLists[0].TxList[i].Hash())
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no transactions lists pushed")
+	}
+
+	// Invalid limits are rejected when subscribing.
+	_, err = ec.c.Subscribe(context.Background(), "taiko", ch, "txPoolContent", core.TxPoolContentLimits{})
+	require.NotNil(t, err)
+}
+
 // randomHash generates a random blob of data and returns it as a hash.
 func randomHash() common.Hash {
 	var hash common.Hash
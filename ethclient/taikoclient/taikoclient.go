@@ -0,0 +1,292 @@
+// Package taikoclient provides an RPC client for the Taiko specific APIs of a L2
+// node: the "taiko_" namespace and the Taiko flavoured "engine_" namespace.
+package taikoclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a wrapper around rpc.Client that implements the Taiko specific APIs.
+//
+// The "engine_" and "taikoAuth_" methods are only served by the authenticated
+// endpoint of a node, use DialWithJWT to connect to it, while the "taiko_" methods
+// are served by its regular endpoints.
+//
+// If you want to use the standardized Ethereum RPC functionality, use ethclient.Client instead.
+type Client struct {
+	c *rpc.Client
+}
+
+// Dial connects a client to the given URL.
+func Dial(rawurl string) (*Client, error) {
+	return DialContext(context.Background(), rawurl)
+}
+
+// DialContext connects a client to the given URL with context.
+func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return New(c), nil
+}
+
+// DialWithJWT connects a client to the given authenticated URL, the requests are
+// authenticated with JWT tokens signed by the given secret, as defined by the
+// Engine API authentication spec.
+func DialWithJWT(ctx context.Context, rawurl string, jwtSecret [32]byte) (*Client, error) {
+	c, err := rpc.DialOptions(ctx, rawurl, rpc.WithHTTPAuth(node.NewJWTAuth(jwtSecret)))
+	if err != nil {
+		return nil, err
+	}
+	return New(c), nil
+}
+
+// New creates a client that uses the given RPC client.
+func New(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// Close closes the underlying RPC connection.
+func (tc *Client) Close() {
+	tc.c.Close()
+}
+
+// Client gets the underlying RPC client.
+func (tc *Client) Client() *rpc.Client {
+	return tc.c
+}
+
+// HeadL1Origin returns the latest L2 block's corresponding L1 origin.
+func (tc *Client) HeadL1Origin(ctx context.Context) (*rawdb.L1Origin, error) {
+	var res *rawdb.L1Origin
+	if err := tc.c.CallContext(ctx, &res, "taiko_headL1Origin"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// L1OriginByID returns the L2 block's corresponding L1 origin.
+func (tc *Client) L1OriginByID(ctx context.Context, blockID *big.Int) (*rawdb.L1Origin, error) {
+	var res *rawdb.L1Origin
+	if err := tc.c.CallContext(ctx, &res, "taiko_l1OriginByID", hexutil.EncodeBig(blockID)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// L1OriginsByL1BlockHash returns the L1 origins of the L2 blocks derived from the
+// given L1 block.
+func (tc *Client) L1OriginsByL1BlockHash(ctx context.Context, l1BlockHash common.Hash) ([]*rawdb.L1Origin, error) {
+	var res []*rawdb.L1Origin
+	if err := tc.c.CallContext(ctx, &res, "taiko_l1OriginsByL1BlockHash", l1BlockHash); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// L1OriginsByL1BlockHeight returns the L1 origins of the L2 blocks derived from
+// the L1 block at the given height.
+func (tc *Client) L1OriginsByL1BlockHeight(ctx context.Context, l1BlockHeight *big.Int) ([]*rawdb.L1Origin, error) {
+	var res []*rawdb.L1Origin
+	if err := tc.c.CallContext(ctx, &res, "taiko_l1OriginsByL1BlockHeight", hexutil.EncodeBig(l1BlockHeight)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RewindToL1Ancestor rewinds the L2 chain to the newest L2 block whose L1 origin is
+// still canonical on L1, and returns the new head L1 origin. The L1 ancestor height
+// is optional if at least one L2 block was derived from that L1 block. Only served
+// by the authenticated endpoint.
+func (tc *Client) RewindToL1Ancestor(ctx context.Context, l1Hash common.Hash, l1Height *big.Int) (*rawdb.L1Origin, error) {
+	var (
+		res  *rawdb.L1Origin
+		args = []interface{}{l1Hash}
+	)
+	if l1Height != nil {
+		args = append(args, hexutil.EncodeBig(l1Height))
+	}
+	if err := tc.c.CallContext(ctx, &res, "taikoAuth_rewindToL1Ancestor", args...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetL1Finality reports the height of the newest finalized L1 block, from which
+// the node derives its safe and finalized L2 blocks. Only served by the
+// authenticated endpoint.
+func (tc *Client) SetL1Finality(ctx context.Context, l1Height *big.Int) error {
+	return tc.c.CallContext(ctx, nil, "taikoAuth_setL1Finality", hexutil.EncodeBig(l1Height))
+}
+
+// SkippedTransactions returns the transactions in the given L2 block's txList,
+// which were skipped while sealing the block.
+func (tc *Client) SkippedTransactions(ctx context.Context, blockID *big.Int) ([]*rawdb.SkippedTransaction, error) {
+	var res []*rawdb.SkippedTransaction
+	if err := tc.c.CallContext(ctx, &res, "taiko_skippedTransactions", hexutil.EncodeBig(blockID)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DepositsByAddress returns at most limit L1 -> L2 ETH deposits credited to the
+// given address, starting from the given deposit index.
+func (tc *Client) DepositsByAddress(ctx context.Context, address common.Address, start uint64, limit uint64) ([]*rawdb.TaikoDeposit, error) {
+	var res []*rawdb.TaikoDeposit
+	if err := tc.c.CallContext(ctx, &res, "taiko_getDepositsByAddress", address, hexutil.Uint64(start), hexutil.Uint64(limit)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// FeeStats returns the fee accounting of the canonical L2 blocks within the given
+// inclusive range.
+func (tc *Client) FeeStats(ctx context.Context, from *big.Int, to *big.Int) ([]*rawdb.TaikoFeeStats, error) {
+	var res []*rawdb.TaikoFeeStats
+	if err := tc.c.CallContext(ctx, &res, "taiko_feeStats", hexutil.EncodeBig(from), hexutil.EncodeBig(to)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BlockWitness returns the witness of the given canonical L2 block, which is
+// enough to re-execute it statelessly. Only served by the authenticated endpoint.
+func (tc *Client) BlockWitness(ctx context.Context, blockID *big.Int) (*core.Witness, error) {
+	var res *core.Witness
+	if err := tc.c.CallContext(ctx, &res, "taikoAuth_getBlockWitness", hexutil.EncodeBig(blockID)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TxPoolContent returns the pending transactions of the transaction pool, split
+// into transactions lists within the given upper limits.
+func (tc *Client) TxPoolContent(
+	ctx context.Context,
+	maxTransactionsPerBlock uint64,
+	blockMaxGasLimit uint64,
+	maxBytesPerTxList uint64,
+	minTxGasLimit uint64,
+	locals []common.Address,
+) ([]types.Transactions, error) {
+	var res []types.Transactions
+	if err := tc.c.CallContext(
+		ctx, &res, "taiko_txPoolContent",
+		maxTransactionsPerBlock, blockMaxGasLimit, maxBytesPerTxList, minTxGasLimit, localsArg(locals),
+	); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TxPoolContentWithOptions returns the pending transactions of the transaction
+// pool, split into transactions lists within the given upper limits, along with
+// the size and the execution information of each list. The options are optional.
+func (tc *Client) TxPoolContentWithOptions(
+	ctx context.Context,
+	maxTransactionsPerBlock uint64,
+	blockMaxGasLimit uint64,
+	maxBytesPerTxList uint64,
+	minTxGasLimit uint64,
+	locals []common.Address,
+	opts *core.TxPoolContentOptions,
+) ([]*core.PreBuiltTxList, error) {
+	var res []*core.PreBuiltTxList
+	if err := tc.c.CallContext(
+		ctx, &res, "taiko_txPoolContentWithOptions",
+		maxTransactionsPerBlock, blockMaxGasLimit, maxBytesPerTxList, minTxGasLimit, localsArg(locals), opts,
+	); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// localsArg converts the given local accounts to the `locals` argument of the
+// `taiko_txPoolContent` calls.
+func localsArg(locals []common.Address) []string {
+	arg := make([]string, len(locals))
+	for i, local := range locals {
+		arg[i] = local.Hex()
+	}
+	return arg
+}
+
+// SubscribeTxPoolContent subscribes to the transactions lists split from the
+// pending transactions of the transaction pool, pushed each time they change.
+// The subscription requires a websocket or IPC connection.
+func (tc *Client) SubscribeTxPoolContent(ctx context.Context, limits core.TxPoolContentLimits, ch chan<- []*core.PreBuiltTxList) (ethereum.Subscription, error) {
+	return tc.c.Subscribe(ctx, "taiko", ch, "txPoolContent", limits)
+}
+
+// TxLanes returns the priority sender lanes configuration used by TxPoolContent,
+// or nil if no lanes are configured. It requires the "admin_" namespace.
+func (tc *Client) TxLanes(ctx context.Context) (*core.TxLanesConfig, error) {
+	var res *core.TxLanesConfig
+	if err := tc.c.CallContext(ctx, &res, "admin_taikoTxLanes"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetTxLanes replaces the priority sender lanes configuration used by TxPoolContent,
+// a nil configuration removes all the lanes. It requires the "admin_" namespace.
+func (tc *Client) SetTxLanes(ctx context.Context, config *core.TxLanesConfig) error {
+	return tc.c.CallContext(ctx, nil, "admin_setTaikoTxLanes", config)
+}
+
+// ForkchoiceUpdated updates the fork choice of the L2 node, and starts building
+// a L2 block with the given Taiko payload attributes if they are not nil. Taiko
+// chains are post-Shanghai, so the V2 engine methods are used.
+func (tc *Client) ForkchoiceUpdated(ctx context.Context, fc *engine.ForkchoiceStateV1, attributes *engine.PayloadAttributes) (*engine.ForkChoiceResponse, error) {
+	var res *engine.ForkChoiceResponse
+	if err := tc.c.CallContext(ctx, &res, "engine_forkchoiceUpdatedV2", fc, attributes); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPayload returns the L2 block built for the given payload ID, along with the
+// transactions of the txList skipped while building it, and the fees credited to
+// the block proposer.
+func (tc *Client) GetPayload(ctx context.Context, payloadID *engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	var res *engine.ExecutionPayloadEnvelope
+	if err := tc.c.CallContext(ctx, &res, "engine_getPayloadV2", payloadID); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NewPayload executes and inserts the given L2 block, without changing the canonical
+// chain. The transactions and withdrawals of the block may be given by their root
+// hashes only, if the block was built by the node itself.
+func (tc *Client) NewPayload(ctx context.Context, payload *engine.ExecutableData) (*engine.PayloadStatusV1, error) {
+	var res *engine.PayloadStatusV1
+	if err := tc.c.CallContext(ctx, &res, "engine_newPayloadV2", payload); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// InsertTaikoBlocks builds and inserts a L2 block for each of the given payload
+// attributes in order, on top of the given parent block, in a single call. The
+// last inserted block becomes the chain head, a status is returned for each
+// attempted block.
+func (tc *Client) InsertTaikoBlocks(ctx context.Context, parentHash common.Hash, attributes []*engine.PayloadAttributes) ([]engine.TaikoBlockStatusV1, error) {
+	var res []engine.TaikoBlockStatusV1
+	if err := tc.c.CallContext(ctx, &res, "engine_insertTaikoBlocksV1", parentHash, attributes); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,321 @@
+package taikoclient
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey, _        = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr          = crypto.PubkeyToAddress(testKey.PublicKey)
+	goldenTouchKey, _ = crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
+	testJWTSecret     = [32]byte{0x01, 0x02, 0x03}
+)
+
+// newTestBackend starts an in-process Taiko node serving the "taiko_" namespace,
+// and the "engine_" namespace on its authenticated endpoint.
+func newTestBackend(t *testing.T) (*node.Node, *eth.Ethereum) {
+	secretFile := filepath.Join(t.TempDir(), "jwtsecret")
+	require.Nil(t, os.WriteFile(secretFile, []byte(hexutil.Encode(testJWTSecret[:])), 0600))
+
+	n, err := node.New(&node.Config{
+		AuthAddr:  "127.0.0.1",
+		AuthPort:  0,
+		JWTSecret: secretFile,
+	})
+	require.Nil(t, err)
+	t.Cleanup(func() { n.Close() })
+
+	config := *params.TaikoChainConfig
+	ethservice, err := eth.New(n, &ethconfig.Config{
+		Genesis: &core.Genesis{
+			Config:     &config,
+			Alloc:      core.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+			Timestamp:  9000,
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: common.Big0,
+		},
+	})
+	require.Nil(t, err)
+	require.Nil(t, catalyst.Register(n, ethservice))
+
+	taikoAPIBackend := eth.NewTaikoAPIBackend(ethservice)
+	n.RegisterAPIs([]rpc.API{
+		{
+			Namespace: "taiko",
+			Version:   params.VersionWithMeta,
+			Service:   taikoAPIBackend,
+			Public:    true,
+		},
+		{
+			Namespace: "taiko",
+			Version:   params.VersionWithMeta,
+			Service:   eth.NewTaikoSubscriptionAPI(taikoAPIBackend),
+			Public:    true,
+		},
+		{
+			Namespace:     "taikoAuth",
+			Version:       params.VersionWithMeta,
+			Service:       eth.NewTaikoAuthAPIBackend(ethservice),
+			Authenticated: true,
+		},
+		{
+			Namespace: "admin",
+			Version:   params.VersionWithMeta,
+			Service:   eth.NewTaikoAdminAPI(ethservice),
+		},
+	})
+	require.Nil(t, n.Start())
+
+	return n, ethservice
+}
+
+// newTestTxList returns the txList of a proposed L2 block, an anchor transaction
+// followed by a transfer.
+func newTestTxList(t *testing.T, config *params.ChainConfig) []byte {
+	signer := types.LatestSigner(config)
+	l2Contract := config.TaikoParams().L2Contract
+
+	txList, err := rlp.EncodeToBytes(types.Transactions{
+		types.MustSignNewTx(goldenTouchKey, signer, &types.LegacyTx{
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      config.TaikoParams().AnchorGasLimit,
+			To:       &l2Contract,
+			Data:     append(common.CopyBytes(taiko.AnchorSelector), make([]byte, 4*32)...),
+		}),
+		types.MustSignNewTx(testKey, signer, &types.LegacyTx{
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+			Gas:      params.TxGas,
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+		}),
+	})
+	require.Nil(t, err)
+	return txList
+}
+
+func TestJWTAuth(t *testing.T) {
+	n, ethservice := newTestBackend(t)
+	head := &engine.ForkchoiceStateV1{HeadBlockHash: ethservice.BlockChain().Genesis().Hash()}
+
+	tc, err := DialWithJWT(context.Background(), n.HTTPAuthEndpoint(), testJWTSecret)
+	require.Nil(t, err)
+	defer tc.Close()
+
+	res, err := tc.ForkchoiceUpdated(context.Background(), head, nil)
+	require.Nil(t, err)
+	require.Equal(t, engine.VALID, res.PayloadStatus.Status)
+
+	// Requests signed with another secret are rejected.
+	invalid, err := DialWithJWT(context.Background(), n.HTTPAuthEndpoint(), [32]byte{0xff})
+	require.Nil(t, err)
+	defer invalid.Close()
+
+	_, err = invalid.ForkchoiceUpdated(context.Background(), head, nil)
+	require.NotNil(t, err)
+}
+
+func TestBuildBlock(t *testing.T) {
+	n, ethservice := newTestBackend(t)
+	config := ethservice.BlockChain().Config()
+	genesis := ethservice.BlockChain().Genesis()
+
+	engineClient, err := DialWithJWT(context.Background(), n.HTTPAuthEndpoint(), testJWTSecret)
+	require.Nil(t, err)
+	defer engineClient.Close()
+
+	rpcClient, err := n.Attach()
+	require.Nil(t, err)
+	tc := New(rpcClient)
+	defer tc.Close()
+
+	_, err = tc.HeadL1Origin(context.Background())
+	require.NotNil(t, err)
+
+	// Build a L2 block from a proposed txList.
+	l1Origin := &rawdb.L1Origin{
+		BlockID:       common.Big1,
+		L1BlockHeight: big.NewInt(100),
+		L1BlockHash:   common.Hash{0x01},
+	}
+	res, err := engineClient.ForkchoiceUpdated(
+		context.Background(),
+		&engine.ForkchoiceStateV1{HeadBlockHash: genesis.Hash()},
+		&engine.PayloadAttributes{
+			Timestamp:             genesis.Time() + 12,
+			SuggestedFeeRecipient: common.Address{0xbb},
+			Withdrawals:           make([]*types.Withdrawal, 0),
+			BaseFeePerGas:         big.NewInt(params.InitialBaseFee),
+			BlockMetadata: &engine.BlockMetadata{
+				Beneficiary:    common.Address{0xbb},
+				GasLimit:       params.GenesisGasLimit,
+				Timestamp:      genesis.Time() + 12,
+				TxList:         newTestTxList(t, config),
+				HighestBlockID: common.Big1,
+			},
+			L1Origin: l1Origin,
+		},
+	)
+	require.Nil(t, err)
+	require.Equal(t, engine.VALID, res.PayloadStatus.Status)
+	require.NotNil(t, res.PayloadID)
+
+	envelope, err := engineClient.GetPayload(context.Background(), res.PayloadID)
+	require.Nil(t, err)
+	require.Positive(t, envelope.BlockValue.Sign())
+
+	payload := envelope.ExecutionPayload
+	require.Equal(t, uint64(1), payload.Number)
+	require.Len(t, payload.Transactions, 2)
+	require.Empty(t, payload.SkippedTransactions)
+
+	status, err := engineClient.NewPayload(context.Background(), payload)
+	require.Nil(t, err)
+	require.Equal(t, engine.VALID, status.Status)
+
+	res, err = engineClient.ForkchoiceUpdated(context.Background(), &engine.ForkchoiceStateV1{HeadBlockHash: payload.BlockHash}, nil)
+	require.Nil(t, err)
+	require.Equal(t, engine.VALID, res.PayloadStatus.Status)
+	require.Equal(t, payload.BlockHash, ethservice.BlockChain().CurrentBlock().Hash())
+
+	// The L1 origin of the new block is tracked.
+	head, err := tc.HeadL1Origin(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, payload.BlockHash, head.L2BlockHash)
+	require.Equal(t, l1Origin.L1BlockHash, head.L1BlockHash)
+
+	byID, err := tc.L1OriginByID(context.Background(), common.Big1)
+	require.Nil(t, err)
+	require.Equal(t, head, byID)
+
+	byHash, err := tc.L1OriginsByL1BlockHash(context.Background(), l1Origin.L1BlockHash)
+	require.Nil(t, err)
+	require.Equal(t, []*rawdb.L1Origin{head}, byHash)
+
+	byHeight, err := tc.L1OriginsByL1BlockHeight(context.Background(), l1Origin.L1BlockHeight)
+	require.Nil(t, err)
+	require.Equal(t, []*rawdb.L1Origin{head}, byHeight)
+
+	skipped, err := tc.SkippedTransactions(context.Background(), common.Big1)
+	require.Nil(t, err)
+	require.Empty(t, skipped)
+
+	stats, err := tc.FeeStats(context.Background(), common.Big0, common.Big1)
+	require.Nil(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, payload.BlockHash, stats[1].BlockHash)
+
+	witness, err := tc.BlockWitness(context.Background(), common.Big1)
+	require.Nil(t, err)
+	require.Equal(t, genesis.Hash(), witness.Headers[0].Hash())
+
+	require.Nil(t, tc.SetL1Finality(context.Background(), l1Origin.L1BlockHeight))
+	require.Equal(t, payload.BlockHash, ethservice.BlockChain().CurrentFinalBlock().Hash())
+
+	// The deposit index is disabled by default.
+	_, err = tc.DepositsByAddress(context.Background(), testAddr, 0, 10)
+	require.NotNil(t, err)
+}
+
+func TestInsertTaikoBlocks(t *testing.T) {
+	n, ethservice := newTestBackend(t)
+	genesis := ethservice.BlockChain().Genesis()
+
+	tc, err := DialWithJWT(context.Background(), n.HTTPAuthEndpoint(), testJWTSecret)
+	require.Nil(t, err)
+	defer tc.Close()
+
+	statuses, err := tc.InsertTaikoBlocks(context.Background(), genesis.Hash(), []*engine.PayloadAttributes{{
+		Timestamp:             genesis.Time() + 12,
+		SuggestedFeeRecipient: common.Address{0xbb},
+		Withdrawals:           make([]*types.Withdrawal, 0),
+		BaseFeePerGas:         big.NewInt(params.InitialBaseFee),
+		BlockMetadata: &engine.BlockMetadata{
+			Beneficiary:    common.Address{0xbb},
+			GasLimit:       params.GenesisGasLimit,
+			Timestamp:      genesis.Time() + 12,
+			TxList:         newTestTxList(t, ethservice.BlockChain().Config()),
+			HighestBlockID: common.Big1,
+		},
+		L1Origin: &rawdb.L1Origin{
+			BlockID:       common.Big1,
+			L1BlockHeight: big.NewInt(100),
+			L1BlockHash:   common.Hash{0x01},
+		},
+	}})
+	require.Nil(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, engine.VALID, statuses[0].Status)
+	require.Equal(t, *statuses[0].BlockHash, ethservice.BlockChain().CurrentBlock().Hash())
+}
+
+func TestTxPoolContent(t *testing.T) {
+	n, ethservice := newTestBackend(t)
+
+	rpcClient, err := n.Attach()
+	require.Nil(t, err)
+	tc := New(rpcClient)
+	defer tc.Close()
+
+	tx := types.MustSignNewTx(testKey, types.LatestSigner(ethservice.BlockChain().Config()), &types.LegacyTx{
+		GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		Gas:      params.TxGas,
+		To:       &common.Address{0xaa},
+		Value:    big.NewInt(1),
+	})
+	require.Nil(t, ethclient.NewClient(rpcClient).SendTransaction(context.Background(), tx))
+
+	txs, err := tc.TxPoolContent(context.Background(), 10, params.TxGas*10, params.MaxCodeSize, params.TxGas, nil)
+	require.Nil(t, err)
+	require.Len(t, txs, 1)
+	require.Len(t, txs[0], 1)
+	require.Equal(t, tx.Hash(), txs[0][0].Hash())
+
+	txLists, err := tc.TxPoolContentWithOptions(context.Background(), 10, params.TxGas*10, params.MaxCodeSize, params.TxGas, nil, nil)
+	require.Nil(t, err)
+	require.Len(t, txLists, 1)
+	require.Len(t, txLists[0].TxList, 1)
+	require.Equal(t, tx.Hash(), txLists[0].TxList[0].Hash())
+
+	txLists, err = tc.TxPoolContentWithOptions(context.Background(), 10, params.TxGas*10, params.MaxCodeSize, params.TxGas, []common.Address{testAddr}, &core.TxPoolContentOptions{Simulate: true})
+	require.Nil(t, err)
+	require.Len(t, txLists, 1)
+	require.Equal(t, params.TxGas, txLists[0].GasUsed)
+
+	// The usage of the priority sender lanes is reported once they're configured.
+	lanes := &core.TxLanesConfig{Lanes: []*core.TxLane{{Name: "test", Senders: []common.Address{testAddr}, MaxTxsPerList: 1}}}
+	require.Nil(t, tc.SetTxLanes(context.Background(), lanes))
+
+	config, err := tc.TxLanes(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, lanes, config)
+
+	txLists, err = tc.TxPoolContentWithOptions(context.Background(), 10, params.TxGas*10, params.MaxCodeSize, params.TxGas, nil, nil)
+	require.Nil(t, err)
+	require.Len(t, txLists, 1)
+	require.Equal(t, []*core.TxLaneUsage{{Lane: "test", Transactions: 1, Gas: hexutil.Uint64(params.TxGas)}}, txLists[0].Lanes)
+
+	require.NotNil(t, tc.SetTxLanes(context.Background(), &core.TxLanesConfig{Policy: "unknown"}))
+	require.Nil(t, tc.SetTxLanes(context.Background(), nil))
+}
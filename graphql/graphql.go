@@ -52,6 +52,12 @@ func (b *Long) UnmarshalGraphQL(input interface{}) error {
 	var err error
 	switch input := input.(type) {
 	case string:
+		// CHANGE(taiko): support the "safe", "finalized" and "preconfirmed" block tags.
+		var tag rpc.BlockNumber
+		if err := tag.UnmarshalJSON([]byte(strconv.Quote(input))); err == nil && tag < rpc.PendingBlockNumber {
+			*b = Long(tag)
+			return nil
+		}
 		// uncomment to support hex values
 		//if strings.HasPrefix(input, "0x") {
 		//	// apply leniency and support hex representations of longs.
@@ -1175,10 +1181,21 @@ func (r *Resolver) Block(ctx context.Context, args struct {
 }) (*Block, error) {
 	var block *Block
 	if args.Number != nil {
-		if *args.Number < 0 {
-			return nil, nil
-		}
 		number := rpc.BlockNumber(*args.Number)
+		// CHANGE(taiko): resolve the block tags, the latest block is returned
+		// when no number is given, and the pending one by the pending query.
+		switch number {
+		case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber, rpc.PreconfirmedBlockNumber:
+			header, err := r.backend.HeaderByNumber(ctx, number)
+			if err != nil || header == nil {
+				return nil, err
+			}
+			number = rpc.BlockNumber(header.Number.Int64())
+		default:
+			if number < 0 {
+				return nil, nil
+			}
+		}
 		numberOrHash := rpc.BlockNumberOrHashWithNumber(number)
 		block = &Block{
 			r:            r,
@@ -133,6 +133,16 @@ func TestGraphQLBlockSerialization(t *testing.T) {
 			want: `{"errors":[{"message":"strconv.ParseInt: parsing \"a\": invalid syntax"}],"data":{}}`,
 			code: 400,
 		},
+		{ // CHANGE(taiko): the preconfirmed block defaults to the latest block
+			body: `{"query": "{block(number:\"preconfirmed\"){number}}","variables": null}`,
+			want: `{"data":{"block":{"number":10}}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{block(number:\"safe\"){number}}","variables": null}`,
+			want: `{"errors":[{"message":"'safe' tag not supported on pre-merge network","path":["block"]}],"data":{"block":null}}`,
+			code: 400,
+		},
 		{
 			body: `{"query": "{bleh{number}}","variables": null}"`,
 			want: `{"errors":[{"message":"Cannot query field \"bleh\" on type \"Query\".","locations":[{"line":1,"column":2}]}]}`,
@@ -69,6 +69,35 @@ const schema string = `
         transaction: Transaction!
     }
 
+    # Deposit is a Taiko L1 -> L2 ETH deposit, credited to its recipient by a
+    # L2 block.
+    type Deposit {
+        # Index is the index of the deposit.
+        index: Long!
+        # Recipient is the account credited by the deposit.
+        recipient(block: Long): Account!
+        # Amount is the amount credited by the deposit, in wei.
+        amount: BigInt!
+        # Block is the block crediting the deposit.
+        block: Block!
+    }
+
+    # L1Origin is the L1 origin of a Taiko L2 block.
+    type L1Origin {
+        # BlockID is the number of the L2 block.
+        blockID: Long!
+        # Block is the L2 block.
+        block: Block!
+        # L1BlockHeight is the number of the L1 block the L2 block was derived
+        # from.
+        l1BlockHeight: Long!
+        # L1BlockHash is the hash of the L1 block the L2 block was derived from.
+        l1BlockHash: Bytes32!
+        # IsPreconfirmed is true if the L2 block was preconfirmed by this node,
+        # and not yet derived from L1.
+        isPreconfirmed: Boolean!
+    }
+
     #EIP-2718
     type AccessTuple{
         address: Address!
@@ -147,6 +176,9 @@ const schema string = `
         # RawReceipt is the canonical encoding of the receipt. For post EIP-2718 typed transactions
         # this is equivalent to TxType || ReceiptEncoding.
         rawReceipt: Bytes!
+        # IsAnchor is true if this transaction is the anchor transaction of a
+        # Taiko L2 block, i.e. the first transaction sent by the anchor sender.
+        isAnchor: Boolean!
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -246,6 +278,15 @@ const schema string = `
         rawHeader: Bytes!
         # Raw is the RLP encoding of the block.
         raw: Bytes!
+        # L1Origin is the L1 origin of this Taiko L2 block, null if the block
+        # was neither derived from L1 nor preconfirmed by this node.
+        l1Origin: L1Origin
+        # TreasuryFee is the base fee paid by the transactions of this Taiko L2
+        # block and credited to the treasury, in wei. Null on non-Taiko chains.
+        treasuryFee: BigInt
+        # Deposits are the Taiko L1 -> L2 ETH deposits credited by the
+        # withdrawals of this block. Null on non-Taiko chains.
+        deposits: [Deposit!]
     }
 
     # CallData represents the data associated with a local contract call.
@@ -331,7 +372,8 @@ const schema string = `
 
     type Query {
         # Block fetches an Ethereum block by number or by hash. If neither is
-        # supplied, the most recent known block is returned.
+        # supplied, the most recent known block is returned. The number can also
+        # be a block tag, e.g. "safe" or "finalized".
         block(number: Long, hash: Bytes32): Block
         # Blocks returns all the blocks between two numbers, inclusive. If
         # to is not supplied, it defaults to the most recent known block.
@@ -352,6 +394,15 @@ const schema string = `
         syncing: SyncState
         # ChainID returns the current chain ID for transaction replay protection.
         chainID: BigInt!
+        # Deposits returns the Taiko L1 -> L2 ETH deposits credited to an
+        # address, in ascending deposit index order, starting from the given
+        # deposit index. The deposits are only known if the node indexes them
+        # (--taiko.depositindex).
+        deposits(address: Address!, start: Long, limit: Long): [Deposit!]!
+        # L1Origins returns the L1 origins of the canonical Taiko L2 blocks
+        # within the given inclusive range, at most 1000 blocks. The blocks
+        # without L1 origin are skipped.
+        l1Origins(from: Long!, to: Long): [L1Origin!]!
     }
 
     type Mutation {
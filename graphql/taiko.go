@@ -0,0 +1,187 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxL1OriginsRange is the maximum number of L2 blocks whose L1 origins are
+// returned by the l1Origins query.
+const maxL1OriginsRange = 1000
+
+// L1Origin represents the L1 origin of a Taiko L2 block.
+type L1Origin struct {
+	r        *Resolver
+	l1Origin *rawdb.L1Origin
+}
+
+func (o *L1Origin) BlockID(ctx context.Context) Long {
+	return Long(o.l1Origin.BlockID.Int64())
+}
+
+func (o *L1Origin) Block(ctx context.Context) *Block {
+	numberOrHash := rpc.BlockNumberOrHashWithHash(o.l1Origin.L2BlockHash, true)
+	return &Block{
+		r:            o.r,
+		numberOrHash: &numberOrHash,
+		hash:         o.l1Origin.L2BlockHash,
+	}
+}
+
+func (o *L1Origin) L1BlockHeight(ctx context.Context) Long {
+	return Long(o.l1Origin.L1BlockHeight.Int64())
+}
+
+func (o *L1Origin) L1BlockHash(ctx context.Context) common.Hash {
+	return o.l1Origin.L1BlockHash
+}
+
+func (o *L1Origin) IsPreconfirmed(ctx context.Context) bool {
+	return o.l1Origin.IsPreconfirmed
+}
+
+// L1Origin returns the L1 origin of the block, if the block was derived from L1
+// or preconfirmed by the node, and is still canonical.
+func (b *Block) L1Origin(ctx context.Context) (*L1Origin, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	l1Origin, err := rawdb.ReadL1Origin(b.r.backend.ChainDb(), header.Number)
+	if err != nil || l1Origin == nil {
+		return nil, err
+	}
+	hash, err := b.Hash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if l1Origin.L2BlockHash != hash {
+		return nil, nil
+	}
+	return &L1Origin{r: b.r, l1Origin: l1Origin}, nil
+}
+
+// TreasuryFee returns the base fee paid by the transactions of the block which is
+// credited to the treasury, it's only set on Taiko chains.
+func (b *Block) TreasuryFee(ctx context.Context) (*hexutil.Big, error) {
+	config := b.r.backend.ChainConfig()
+	if !config.Taiko {
+		return nil, nil
+	}
+	hash, err := b.Hash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := rawdb.ReadTaikoFeeStats(b.r.backend.ChainDb(), hash)
+	if err != nil {
+		return nil, err
+	}
+	// The fee accounting of the blocks which were not executed locally is
+	// derived from their receipts.
+	if stats == nil {
+		block, err := b.resolve(ctx)
+		if err != nil || block == nil {
+			return nil, err
+		}
+		receipts, err := b.resolveReceipts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats = core.CalcTaikoFeeStats(config, block, receipts)
+	}
+	return (*hexutil.Big)(stats.TreasuryBaseFee), nil
+}
+
+// Deposits returns the L1 -> L2 ETH deposits credited by the withdrawals of the
+// block, it's only set on Taiko chains.
+func (b *Block) Deposits(ctx context.Context) (*[]*Deposit, error) {
+	if !b.r.backend.ChainConfig().Taiko {
+		return nil, nil
+	}
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := make([]*Deposit, 0, len(block.Withdrawals()))
+	for _, w := range block.Withdrawals() {
+		ret = append(ret, &Deposit{
+			r: b.r,
+			deposit: &rawdb.TaikoDeposit{
+				Index:       w.Index,
+				Recipient:   w.Address,
+				Amount:      w.Amount,
+				BlockHash:   block.Hash(),
+				BlockNumber: block.NumberU64(),
+			},
+		})
+	}
+	return &ret, nil
+}
+
+// IsAnchor returns whether the transaction is the anchor transaction of a Taiko
+// L2 block, i.e. the first transaction of the block sent by the anchor sender.
+func (t *Transaction) IsAnchor(ctx context.Context) (bool, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return false, err
+	}
+	config := t.r.backend.ChainConfig()
+	if !config.Taiko || t.block == nil || t.index != 0 {
+		return false, nil
+	}
+	from, err := types.Sender(types.LatestSigner(config), tx)
+	if err != nil {
+		return false, nil
+	}
+	return from == config.TaikoParams().AnchorSender, nil
+}
+
+// L1Origins returns the L1 origins of the canonical L2 blocks within the given
+// inclusive range, the blocks without L1 origin are skipped.
+func (r *Resolver) L1Origins(ctx context.Context, args struct {
+	From Long
+	To   *Long
+}) ([]*L1Origin, error) {
+	if args.From < 0 {
+		return nil, fmt.Errorf("invalid from %d", args.From)
+	}
+	if args.To != nil && *args.To < 0 {
+		return nil, fmt.Errorf("invalid to %d", *args.To)
+	}
+	var (
+		db   = r.backend.ChainDb()
+		head = r.backend.CurrentBlock().Number.Uint64()
+		from = uint64(args.From)
+		to   = head
+	)
+	if args.To != nil && uint64(*args.To) < to {
+		to = uint64(*args.To)
+	}
+	if to < from {
+		return []*L1Origin{}, nil
+	}
+	if to-from >= maxL1OriginsRange {
+		return nil, fmt.Errorf("range too large, at most %d blocks", maxL1OriginsRange)
+	}
+	ret := make([]*L1Origin, 0, to-from+1)
+	for number := from; number <= to; number++ {
+		l1Origin, err := rawdb.ReadL1Origin(db, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, err
+		}
+		// Skip the stale L1 origins of reorged L2 blocks.
+		if l1Origin == nil || rawdb.VerifyL1Origin(db, l1Origin, head) != nil {
+			continue
+		}
+		ret = append(ret, &L1Origin{r: r, l1Origin: l1Origin})
+	}
+	return ret, nil
+}
@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultDepositsPageSize is the number of deposits returned by the deposits
+	// query if no limit is given.
+	defaultDepositsPageSize = 100
+
+	// maxDepositsPageSize is the maximum number of deposits returned by the
+	// deposits query.
+	maxDepositsPageSize = 1000
+)
+
+// Deposit represents a L1 -> L2 ETH deposit, credited to its recipient by a Taiko
+// L2 block.
+type Deposit struct {
+	r       *Resolver
+	deposit *rawdb.TaikoDeposit
+}
+
+func (d *Deposit) Index(ctx context.Context) Long {
+	return Long(d.deposit.Index)
+}
+
+func (d *Deposit) Recipient(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{
+		r:             d.r,
+		address:       d.deposit.Recipient,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (d *Deposit) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(d.deposit.Amount))
+}
+
+func (d *Deposit) Block(ctx context.Context) *Block {
+	numberOrHash := rpc.BlockNumberOrHashWithHash(d.deposit.BlockHash, true)
+	return &Block{
+		r:            d.r,
+		numberOrHash: &numberOrHash,
+		hash:         d.deposit.BlockHash,
+	}
+}
+
+// Deposits returns the L1 -> L2 ETH deposits credited to the given address in the
+// canonical chain, in ascending deposit index order. The deposits are only known
+// if the node indexes them (--taiko.depositindex).
+func (r *Resolver) Deposits(ctx context.Context, args struct {
+	Address common.Address
+	Start   *Long
+	Limit   *Long
+}) ([]*Deposit, error) {
+	var (
+		start = uint64(0)
+		limit = defaultDepositsPageSize
+	)
+	if args.Start != nil {
+		if *args.Start < 0 {
+			return nil, fmt.Errorf("invalid start %d", *args.Start)
+		}
+		start = uint64(*args.Start)
+	}
+	if args.Limit != nil {
+		if *args.Limit <= 0 || *args.Limit > maxDepositsPageSize {
+			return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", *args.Limit, maxDepositsPageSize)
+		}
+		limit = int(*args.Limit)
+	}
+	deposits, err := rawdb.ReadTaikoDepositsByAddress(r.backend.ChainDb(), args.Address, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Deposit, 0, len(deposits))
+	for _, deposit := range deposits {
+		ret = append(ret, &Deposit{r: r, deposit: deposit})
+	}
+	return ret, nil
+}
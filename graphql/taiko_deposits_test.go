@@ -0,0 +1,87 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLTaikoDeposits(t *testing.T) {
+	var (
+		recipient = common.HexToAddress("0xbb")
+		genesis   = &core.Genesis{
+			Config:     params.AllEthashProtocolChanges,
+			GasLimit:   11500000,
+			Difficulty: big.NewInt(1048576),
+		}
+		stack = createNode(t)
+	)
+	defer stack.Close()
+
+	ethBackend, err := eth.New(stack, &ethconfig.Config{
+		Genesis:   genesis,
+		Ethash:    ethash.Config{PowMode: ethash.ModeFake},
+		NetworkId: 1337,
+	})
+	require.Nil(t, err)
+	chain, _ := core.GenerateChain(params.AllEthashProtocolChanges, ethBackend.BlockChain().Genesis(),
+		ethash.NewFaker(), ethBackend.ChainDb(), 3, func(i int, gen *core.BlockGen) {})
+	_, err = ethBackend.BlockChain().InsertChain(chain)
+	require.Nil(t, err)
+
+	// Index a deposit per block, and a deposit of a reorged block.
+	db := ethBackend.ChainDb()
+	for i, block := range chain {
+		rawdb.WriteTaikoDeposit(db, &rawdb.TaikoDeposit{
+			Index:       uint64(i),
+			Recipient:   recipient,
+			Amount:      uint64(i+1) * params.GWei,
+			BlockHash:   block.Hash(),
+			BlockNumber: block.NumberU64(),
+		})
+	}
+	rawdb.WriteTaikoDeposit(db, &rawdb.TaikoDeposit{Index: 3, Recipient: recipient, Amount: 1, BlockHash: common.Hash{0x01}, BlockNumber: 3})
+
+	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{})
+	handler, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{})
+	require.Nil(t, err)
+	require.Nil(t, stack.Start())
+
+	for _, tt := range []struct {
+		query string
+		want  string
+	}{
+		{
+			query: `{deposits(address: "0x00000000000000000000000000000000000000bb") { index amount recipient { address } block { number } } }`,
+			want:  `{"deposits":[{"index":0,"amount":"0x3b9aca00","recipient":{"address":"0x00000000000000000000000000000000000000bb"},"block":{"number":1}},{"index":1,"amount":"0x77359400","recipient":{"address":"0x00000000000000000000000000000000000000bb"},"block":{"number":2}},{"index":2,"amount":"0xb2d05e00","recipient":{"address":"0x00000000000000000000000000000000000000bb"},"block":{"number":3}}]}`,
+		},
+		{
+			query: `{deposits(address: "0x00000000000000000000000000000000000000bb", start: 1, limit: 1) { index } }`,
+			want:  `{"deposits":[{"index":1}]}`,
+		},
+		{
+			query: `{deposits(address: "0x00000000000000000000000000000000000000aa") { index } }`,
+			want:  `{"deposits":[]}`,
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.query, "", map[string]interface{}{})
+		require.Nil(t, res.Errors)
+		have, err := json.Marshal(res.Data)
+		require.Nil(t, err)
+		require.Equal(t, tt.want, string(have))
+	}
+
+	res := handler.Schema.Exec(context.Background(), `{deposits(address: "0x00000000000000000000000000000000000000bb", limit: 0) { index } }`, "", map[string]interface{}{})
+	require.NotNil(t, res.Errors)
+}
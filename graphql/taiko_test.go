@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLTaikoFields(t *testing.T) {
+	var (
+		goldenTouchKey, _ = crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
+		key, _            = crypto.GenerateKey()
+		addr              = crypto.PubkeyToAddress(key.PublicKey)
+		config            = *params.TaikoChainConfig
+		genesis           = &core.Genesis{
+			Config:     &config,
+			Alloc:      core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			Timestamp:  9000,
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: common.Big0,
+		}
+		signer     = types.LatestSigner(&config)
+		l2Contract = config.TaikoParams().L2Contract
+		stack      = createNode(t)
+	)
+	defer stack.Close()
+
+	ethBackend, err := eth.New(stack, &ethconfig.Config{Genesis: genesis, NetworkId: 1337})
+	require.Nil(t, err)
+
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, taiko.New(nil), 3, func(i int, g *core.BlockGen) {
+		g.OffsetTime(5)
+		g.SetDifficulty(common.Big0)
+		g.AddTx(types.MustSignNewTx(goldenTouchKey, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      params.AnchorGasLimit,
+			To:       &l2Contract,
+			Data:     append(common.CopyBytes(taiko.AnchorSelector), make([]byte, 4*32)...),
+		}))
+		g.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+			Gas:      params.TxGas,
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+		}))
+		if i == 0 {
+			g.AddWithdrawal(&types.Withdrawal{Address: common.Address{0xbb}, Amount: params.GWei})
+		}
+	})
+	_, err = ethBackend.BlockChain().InsertChain(blocks)
+	require.Nil(t, err)
+
+	// The first two blocks are derived from L1, the L1 origin of the last block
+	// belongs to a reorged block.
+	db := ethBackend.ChainDb()
+	for i, block := range blocks {
+		l2BlockHash := block.Hash()
+		if i == 2 {
+			l2BlockHash = common.Hash{0x01}
+		}
+		rawdb.WriteL1Origin(db, block.Number(), &rawdb.L1Origin{
+			BlockID:       block.Number(),
+			L2BlockHash:   l2BlockHash,
+			L1BlockHeight: big.NewInt(int64(100 + i)),
+			L1BlockHash:   common.Hash{byte(i + 1)},
+		})
+	}
+
+	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{})
+	handler, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{})
+	require.Nil(t, err)
+	require.Nil(t, stack.Start())
+
+	stats, err := ethBackend.BlockChain().GetTaikoFeeStats(blocks[0].Hash(), 1)
+	require.Nil(t, err)
+	require.NotZero(t, stats.TreasuryBaseFee.Sign())
+
+	for _, tt := range []struct {
+		query string
+		want  string
+	}{
+		{
+			query: `{block(number: 1) { l1Origin { blockID l1BlockHeight l1BlockHash isPreconfirmed block { number } } treasuryFee deposits { amount recipient { address } } transactions { isAnchor } } }`,
+			want: fmt.Sprintf(`{"block":{"l1Origin":{"blockID":1,"l1BlockHeight":100,"l1BlockHash":"%s","isPreconfirmed":false,"block":{"number":1}},"treasuryFee":"%#x","deposits":[{"amount":"0x3b9aca00","recipient":{"address":"0xbb00000000000000000000000000000000000000"}}],"transactions":[{"isAnchor":true},{"isAnchor":false}]}}`,
+				common.Hash{0x01}.Hex(), stats.TreasuryBaseFee),
+		},
+		{
+			query: `{block(number: 3) { l1Origin { blockID } deposits { amount } } }`,
+			want:  `{"block":{"l1Origin":null,"deposits":[]}}`,
+		},
+		{
+			query: `{l1Origins(from: 0) { blockID l1BlockHeight } }`,
+			want:  `{"l1Origins":[{"blockID":1,"l1BlockHeight":100},{"blockID":2,"l1BlockHeight":101}]}`,
+		},
+		{
+			query: `{l1Origins(from: 2, to: 2) { blockID } }`,
+			want:  `{"l1Origins":[{"blockID":2}]}`,
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.query, "", map[string]interface{}{})
+		require.Nil(t, res.Errors)
+		have, err := json.Marshal(res.Data)
+		require.Nil(t, err)
+		require.Equal(t, tt.want, string(have))
+	}
+
+	// The range is capped at the chain head.
+	res := handler.Schema.Exec(context.Background(), `{l1Origins(from: 0, to: 1000) { blockID } }`, "", map[string]interface{}{})
+	require.Nil(t, res.Errors)
+	res = handler.Schema.Exec(context.Background(), `{l1Origins(from: -1) { blockID } }`, "", map[string]interface{}{})
+	require.NotNil(t, res.Errors)
+}
@@ -71,6 +71,10 @@ func (b *LesApiBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	if number == rpc.LatestBlockNumber {
 		return b.eth.blockchain.CurrentHeader(), nil
 	}
+	// CHANGE(taiko): the light client doesn't track the preconfirmed blocks.
+	if number == rpc.PreconfirmedBlockNumber {
+		return nil, errors.New("'preconfirmed' tag not supported by light clients")
+	}
 	return b.eth.blockchain.GetHeaderByNumberOdr(ctx, uint64(number))
 }
 
@@ -5,10 +5,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-// SealBlockWith mines and seals a block without changing the canonical chain.
+// SealBlockWith mines and seals a block without changing the canonical chain, it
+// also returns the transactions in the txList skipped while sealing the block, and
+// the fees credited to the block proposer.
 func (miner *Miner) SealBlockWith(
 	parent common.Hash,
 	timestamp uint64,
@@ -16,6 +19,12 @@ func (miner *Miner) SealBlockWith(
 	baseFeePerGas *big.Int,
 	withdrawals types.Withdrawals,
 	withdrawalsHash common.Hash,
-) (*types.Block, error) {
+) (*types.Block, []*rawdb.SkippedTransaction, *big.Int, error) {
 	return miner.worker.sealBlockWith(parent, timestamp, blkMeta, baseFeePerGas, withdrawals, withdrawalsHash)
 }
+
+// NewTxSimulator creates a new transactions simulator on top of the current chain
+// head state, the caller must discard it when done.
+func (miner *Miner) NewTxSimulator() (*TxSimulator, error) {
+	return miner.worker.newTxSimulator()
+}
@@ -3,17 +3,22 @@ package miner
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/params"
 )
 
-// sealBlockWith mines and seals a block with the given block metadata.
+// sealBlockWith mines and seals a block with the given block metadata, and returns
+// the transactions in the txList which were skipped while sealing the block, and
+// the fees credited to the block proposer.
 func (w *worker) sealBlockWith(
 	parent common.Hash,
 	timestamp uint64,
@@ -21,17 +26,17 @@ func (w *worker) sealBlockWith(
 	baseFeePerGas *big.Int,
 	withdrawals types.Withdrawals,
 	withdrawalsHash common.Hash,
-) (*types.Block, error) {
-	// Decode transactions bytes.
-	var txs types.Transactions
-	if err := rlp.DecodeBytes(blkMeta.TxList, &txs); err != nil {
-		return nil, fmt.Errorf("failed to decode txList: %w", err)
+) (*types.Block, []*rawdb.SkippedTransaction, *big.Int, error) {
+	// Decode transactions bytes, a malformed txList is salvaged as long as the
+	// anchor transaction can be decoded, since a L2 block needs to have at least
+	// one `V1TaikoL2.anchor` or `V1TaikoL2.invalidateBlock` transaction. Without
+	// it, the block metadata is rejected, see core.DecodeTxList.
+	txs, dropped, err := core.DecodeTxList(w.chainConfig, blkMeta.TxList)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode txList: %w", err)
 	}
-
-	if len(txs) == 0 {
-		// A L2 block needs to have have at least one `V1TaikoL2.anchor` or
-		// `V1TaikoL2.invalidateBlock` transaction.
-		return nil, fmt.Errorf("too less transactions in the block")
+	for _, tx := range dropped {
+		log.Warn("Drop transactions of a proposed txList", "index", tx.Index, "hash", tx.Hash, "reason", tx.Reason)
 	}
 
 	params := &generateParams{
@@ -48,7 +53,7 @@ func (w *worker) sealBlockWith(
 
 	env, err := w.prepareWork(params)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	defer env.discard()
 
@@ -61,7 +66,7 @@ func (w *worker) sealBlockWith(
 	env.header.WithdrawalsHash = &withdrawalsHash
 
 	// Commit transactions.
-	commitErrs := make([]error, 0, len(txs))
+	skipped := append(make([]*rawdb.SkippedTransaction, 0), dropped...)
 	gasLimit := env.header.GasLimit
 	rules := w.chain.Config().Rules(env.header.Number, true, timestamp)
 
@@ -71,7 +76,15 @@ func (w *worker) sealBlockWith(
 		sender, err := types.LatestSignerForChainID(tx.ChainId()).Sender(tx)
 		if err != nil {
 			log.Info("Skip an invalid proposed transaction", "hash", tx.Hash(), "reason", err)
-			commitErrs = append(commitErrs, err)
+			skipped = append(skipped, &rawdb.SkippedTransaction{Index: uint64(i), Hash: tx.Hash(), Reason: err.Error()})
+			continue
+		}
+
+		// Only the anchor transaction can be sent by the anchor transaction sender,
+		// otherwise the block would be rejected by the consensus engine.
+		if i != 0 && sender == w.chainConfig.TaikoParams().AnchorSender {
+			log.Info("Skip an invalid proposed transaction", "hash", tx.Hash(), "reason", taiko.ErrAnchorTxNotFirst)
+			skipped = append(skipped, &rawdb.SkippedTransaction{Index: uint64(i), Hash: tx.Hash(), Reason: taiko.ErrAnchorTxNotFirst.Error()})
 			continue
 		}
 
@@ -79,24 +92,94 @@ func (w *worker) sealBlockWith(
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 		if _, err := w.commitTransaction(env, tx, i == 0); err != nil {
 			log.Info("Skip an invalid proposed transaction", "hash", tx.Hash(), "reason", err)
-			commitErrs = append(commitErrs, err)
+			skipped = append(skipped, &rawdb.SkippedTransaction{Index: uint64(i), Hash: tx.Hash(), Reason: err.Error()})
 			continue
 		}
 		env.tcount++
 	}
-	// TODO: save the commit transactions errors for generating witness.
-	_ = commitErrs
 
 	block, err := w.engine.FinalizeAndAssemble(w.chain, env.header, env.state, env.txs, nil, env.receipts, withdrawals)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	results := make(chan *types.Block, 1)
 	if err := w.engine.Seal(w.chain, block, results, nil); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	block = <-results
 
-	return block, nil
+	return block, skipped, core.CalcTaikoFeeStats(w.chainConfig, block, env.receipts).ProposerFees(), nil
+}
+
+// TxSimulator executes transactions on top of the current chain head state, using
+// the same path as sealing a block. It implements core.TxSimulator.
+type TxSimulator struct {
+	w     *worker
+	env   *environment
+	rules params.Rules
+}
+
+// newTxSimulator creates a new TxSimulator on top of the current chain head state.
+func (w *worker) newTxSimulator() (*TxSimulator, error) {
+	parent := w.chain.CurrentBlock()
+
+	timestamp := uint64(time.Now().Unix())
+	if parent.Time > timestamp {
+		timestamp = parent.Time
+	}
+
+	env, err := w.prepareWork(&generateParams{
+		timestamp:     timestamp,
+		forceTime:     true,
+		parentHash:    parent.Hash(),
+		coinbase:      w.etherbase(),
+		noUncle:       true,
+		noTxs:         true,
+		baseFeePerGas: parent.BaseFee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxSimulator{
+		w:     w,
+		env:   env,
+		rules: w.chain.Config().Rules(env.header.Number, true, env.header.Time),
+	}, nil
+}
+
+// ResetGasPool starts a new transactions list with the given gas limit, the state
+// changes of the previous lists are kept.
+func (s *TxSimulator) ResetGasPool(gasLimit uint64) {
+	s.env.header.GasLimit = gasLimit
+	s.env.header.GasUsed = 0
+	s.env.gasPool = new(core.GasPool).AddGas(gasLimit)
+}
+
+// Apply executes the given transaction, its state changes are only kept on success.
+// It returns the gas used and the tips paid by the transaction.
+func (s *TxSimulator) Apply(tx *types.Transaction) (uint64, *big.Int, error) {
+	sender, err := types.Sender(s.env.signer, tx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.env.state.Prepare(s.rules, sender, s.env.coinbase, tx.To(), vm.ActivePrecompiles(s.rules), tx.AccessList())
+	s.env.state.SetTxContext(tx.Hash(), s.env.tcount)
+	if _, err := s.w.commitTransaction(s.env, tx, false); err != nil {
+		return 0, nil, err
+	}
+	s.env.tcount++
+
+	var (
+		gasUsed = s.env.receipts[len(s.env.receipts)-1].GasUsed
+		tip, _  = tx.EffectiveGasTip(s.env.header.BaseFee) // Fee cap already checked when applying
+	)
+	return gasUsed, new(big.Int).Mul(tip, new(big.Int).SetUint64(gasUsed)), nil
+}
+
+// Discard releases the resources held by the simulator.
+func (s *TxSimulator) Discard() {
+	s.env.discard()
 }
@@ -0,0 +1,201 @@
+package miner
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/taiko"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaikoTestWorker(t *testing.T) (*worker, *testWorkerBackend) {
+	config := *params.TestChainConfig
+	config.Taiko = true
+
+	w, b := newTestWorker(t, &config, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	t.Cleanup(w.close)
+
+	return w, b
+}
+
+func newTaikoTestTx(nonce uint64) *types.Transaction {
+	return types.MustSignNewTx(testBankKey, types.LatestSigner(params.TestChainConfig), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+}
+
+func TestSealBlockWithSkippedTransactions(t *testing.T) {
+	w, b := newTaikoTestWorker(t)
+
+	txs := types.Transactions{
+		newTaikoTestTx(0),
+		newTaikoTestTx(5), // nonce too high
+		newTaikoTestTx(1),
+	}
+	txList, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+
+	block, skipped, _, err := w.sealBlockWith(
+		b.chain.CurrentBlock().Hash(),
+		uint64(time.Now().Unix()),
+		&engine.BlockMetadata{
+			Beneficiary:    common.HexToAddress("0xdeadbeef"),
+			GasLimit:       params.GenesisGasLimit,
+			Timestamp:      uint64(time.Now().Unix()),
+			TxList:         txList,
+			HighestBlockID: common.Big1,
+		},
+		big.NewInt(params.InitialBaseFee),
+		nil,
+		types.EmptyWithdrawalsHash,
+	)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(block.Transactions()))
+	require.Len(t, skipped, 1)
+	require.Equal(t, uint64(1), skipped[0].Index)
+	require.Equal(t, txs[1].Hash(), skipped[0].Hash)
+	require.NotEmpty(t, skipped[0].Reason)
+}
+
+func TestSealBlockWithMalformedTxList(t *testing.T) {
+	w, b := newTaikoTestWorker(t)
+
+	txs := types.Transactions{
+		newTaikoTestTx(0),
+		newTaikoTestTx(1),
+	}
+	txList, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+
+	block, skipped, _, err := w.sealBlockWith(
+		b.chain.CurrentBlock().Hash(),
+		uint64(time.Now().Unix()),
+		&engine.BlockMetadata{
+			Beneficiary:    common.HexToAddress("0xdeadbeef"),
+			GasLimit:       params.GenesisGasLimit,
+			Timestamp:      uint64(time.Now().Unix()),
+			TxList:         txList[:len(txList)-1], // the last transaction is truncated
+			HighestBlockID: common.Big1,
+		},
+		big.NewInt(params.InitialBaseFee),
+		nil,
+		types.EmptyWithdrawalsHash,
+	)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(block.Transactions()))
+	require.Len(t, skipped, 1)
+	require.Equal(t, uint64(1), skipped[0].Index)
+	require.Contains(t, skipped[0].Reason, core.ErrTxListMalformed.Error())
+
+	// A txList without anchor transaction is rejected.
+	_, _, _, err = w.sealBlockWith(
+		b.chain.CurrentBlock().Hash(),
+		uint64(time.Now().Unix()),
+		&engine.BlockMetadata{
+			Beneficiary:    common.HexToAddress("0xdeadbeef"),
+			GasLimit:       params.GenesisGasLimit,
+			Timestamp:      uint64(time.Now().Unix()),
+			TxList:         []byte{0xc0},
+			HighestBlockID: common.Big1,
+		},
+		big.NewInt(params.InitialBaseFee),
+		nil,
+		types.EmptyWithdrawalsHash,
+	)
+	require.ErrorIs(t, err, core.ErrTxListAnchorMissing)
+}
+
+func TestSealBlockWithAnchorSenderTransactions(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Taiko = true
+	config.TaikoConfig = &params.TaikoConfig{AnchorSender: testBankAddress}
+
+	w, b := newTestWorker(t, &config, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	defer w.close()
+
+	txs := types.Transactions{
+		newTaikoTestTx(0),
+		newTaikoTestTx(1), // sent by the anchor transaction sender
+	}
+	txList, err := rlp.EncodeToBytes(txs)
+	require.Nil(t, err)
+
+	block, skipped, _, err := w.sealBlockWith(
+		b.chain.CurrentBlock().Hash(),
+		uint64(time.Now().Unix()),
+		&engine.BlockMetadata{
+			Beneficiary:    common.HexToAddress("0xdeadbeef"),
+			GasLimit:       params.GenesisGasLimit,
+			Timestamp:      uint64(time.Now().Unix()),
+			TxList:         txList,
+			HighestBlockID: common.Big1,
+		},
+		big.NewInt(params.InitialBaseFee),
+		nil,
+		types.EmptyWithdrawalsHash,
+	)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(block.Transactions()))
+	require.Len(t, skipped, 1)
+	require.Equal(t, txs[1].Hash(), skipped[0].Hash)
+	require.Equal(t, taiko.ErrAnchorTxNotFirst.Error(), skipped[0].Reason)
+}
+
+func TestTxSimulatorSplit(t *testing.T) {
+	w, _ := newTaikoTestWorker(t)
+
+	simulator, err := w.newTxSimulator()
+	require.Nil(t, err)
+	defer simulator.Discard()
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	txs := types.Transactions{
+		types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    0,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas * 10, // Declared gas is much higher than the actual gas used
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		}),
+		types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    1,
+			To:       &testUserAddress,
+			Value:    testBankFunds, // Insufficient funds
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		}),
+	}
+
+	splitter, err := core.NewPoolContentSplitter(
+		params.TestChainConfig.ChainID,
+		10,
+		params.TxGas*10,
+		params.MaxCodeSize,
+		params.TxGas,
+		nil,
+		core.TxListCompressionNone,
+		simulator,
+		nil,
+	)
+	require.Nil(t, err)
+
+	txLists := splitter.Split(core.PoolContent{testBankAddress: txs})
+	require.Len(t, txLists, 1)
+	require.Equal(t, 1, txLists[0].TxList.Len())
+	require.Equal(t, txs[0].Hash(), txLists[0].TxList[0].Hash())
+	require.Equal(t, params.TxGas, txLists[0].GasUsed)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(params.InitialBaseFee), new(big.Int).SetUint64(params.TxGas)), txLists[0].Tips)
+}
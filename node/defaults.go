@@ -43,7 +43,10 @@ var (
 	DefaultAuthVhosts  = []string{"localhost"} // Default virtual hosts for the authenticated apis
 	DefaultAuthOrigins = []string{"localhost"} // Default origins for the authenticated apis
 	DefaultAuthPrefix  = ""                    // Default prefix for the authenticated apis
-	DefaultAuthModules = []string{"eth", "engine"}
+
+	// CHANGE(taiko): the Taiko APIs modifying the chain are only served by the
+	// authenticated endpoint, along with the engine API.
+	DefaultAuthModules = []string{"eth", "engine", "taikoAuth"}
 )
 
 // DefaultConfig contains reasonable default settings.
@@ -459,9 +459,12 @@ type ChainConfig struct {
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
 
-	// CHANGE(taiko): Taiko network flag.
-	Taiko    bool           `json:"taiko"`
-	Treasury common.Address `json:"treasury"`
+	// CHANGE(taiko): Taiko network flag and protocol settings.
+	Taiko       bool         `json:"taiko"`
+	TaikoConfig *TaikoConfig `json:"taikoConfig,omitempty"`
+
+	// CHANGE(taiko): Taiko hard forks, scheduled by L2 block number.
+	OntakeBlock *big.Int `json:"ontakeBlock,omitempty"` // Ontake switch block (nil = no fork, 0 = already on ontake)
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -572,6 +575,14 @@ func (c *ChainConfig) Description() string {
 	if c.PragueTime != nil {
 		banner += fmt.Sprintf(" - Prague:                      @%-10v\n", *c.PragueTime)
 	}
+	// CHANGE(taiko): list the Taiko hard forks.
+	if c.Taiko {
+		banner += "\n"
+		banner += "Taiko hard forks:\n"
+		if c.OntakeBlock != nil {
+			banner += fmt.Sprintf(" - Ontake:                      #%-8v\n", c.OntakeBlock)
+		}
+	}
 	return banner
 }
 
@@ -759,7 +770,13 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
-	return nil
+	// CHANGE(taiko): Taiko hard forks are scheduled independently of the Ethereum ones.
+	if err := c.checkTaikoForkOrder(); err != nil {
+		return err
+	}
+	// CHANGE(taiko): the Taiko protocol settings must allow verifying the anchor
+	// transaction and deriving a base fee.
+	return c.TaikoParams().check()
 }
 
 func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int, headTimestamp uint64) *ConfigCompatError {
@@ -827,6 +844,13 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.PragueTime, newcfg.PragueTime, headTimestamp) {
 		return newTimestampCompatError("Prague fork timestamp", c.PragueTime, newcfg.PragueTime)
 	}
+	// CHANGE(taiko): check the Taiko hard forks.
+	if isForkBlockIncompatible(c.OntakeBlock, newcfg.OntakeBlock, headNumber) {
+		return newBlockCompatError("Ontake fork block", c.OntakeBlock, newcfg.OntakeBlock)
+	}
+	if isForkBlockIncompatible(c.taikoBaseFeeActivationBlock(), newcfg.taikoBaseFeeActivationBlock(), headNumber) {
+		return newBlockCompatError("Taiko base fee activation block", c.taikoBaseFeeActivationBlock(), newcfg.taikoBaseFeeActivationBlock())
+	}
 	return nil
 }
 
@@ -972,6 +996,7 @@ type Rules struct {
 	IsByzantium, IsConstantinople, IsPetersburg, IsIstanbul bool
 	IsBerlin, IsLondon                                      bool
 	IsMerge, IsShanghai, isCancun, isPrague                 bool
+	IsOntake                                                bool // CHANGE(taiko): Taiko hard forks
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -996,5 +1021,6 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 		IsShanghai:       c.IsShanghai(timestamp),
 		isCancun:         c.IsCancun(timestamp),
 		isPrague:         c.IsPrague(timestamp),
+		IsOntake:         c.IsOntake(num),
 	}
 }
@@ -1,13 +1,338 @@
 package params
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 func u64(val uint64) *uint64 { return &val }
 
+// Default Taiko protocol addresses and constants.
+var (
+	// GoldenTouchAccount is the default sender of the TaikoL2.anchor transaction.
+	GoldenTouchAccount = common.HexToAddress("0x0000777735367b36bC9B61C50022d9D0700dB4Ec")
+
+	// TaikoL2Address is the default address of the predeployed TaikoL2 contract.
+	TaikoL2Address = common.HexToAddress("0x1000777700000000000000000000000000000001")
+
+	// TaikoL2LegacyAddress is the address of the predeployed TaikoL2 contract in the
+	// networks which were deployed before the TaikoL2 proxy was introduced.
+	TaikoL2LegacyAddress = common.HexToAddress("0x0000777700000000000000000000000000000001")
+
+	// TaikoTreasury is the default treasury, which receives the L2 base fee.
+	TaikoTreasury = common.HexToAddress("0xdf09A0afD09a63fb04ab3573922437e1e637dE8b")
+
+	// AnchorGasLimit is the default gas limit of the TaikoL2.anchor transaction.
+	AnchorGasLimit = uint64(250_000)
+)
+
+// DefaultAnchorMethod is the default signature of the TaikoL2 method called by the
+// anchor transaction before Ontake.
+const DefaultAnchorMethod = "anchor(bytes32,bytes32,uint64,uint32)"
+
+const (
+	// TaikoDepositIndexBlocks is the number of blocks a single deposit index
+	// section covers.
+	TaikoDepositIndexBlocks uint64 = 32
+
+	// TaikoDepositIndexConfirms is the number of confirmation blocks before a
+	// deposit index section is indexed, a reorged section is indexed again.
+	TaikoDepositIndexConfirms = 0
+)
+
+// DefaultTaikoConfig is the Taiko protocol settings used by a Taiko chain config
+// without a `taikoConfig` section.
+var DefaultTaikoConfig = &TaikoConfig{
+	AnchorSender:   GoldenTouchAccount,
+	L2Contract:     TaikoL2Address,
+	Treasury:       TaikoTreasury,
+	AnchorGasLimit: AnchorGasLimit,
+}
+
+// TaikoConfig is the Taiko protocol settings of a Taiko network, which can differ
+// between deployments.
+type TaikoConfig struct {
+	AnchorSender   common.Address `json:"anchorSender"`   // The only account allowed to send the TaikoL2.anchor transaction
+	L2Contract     common.Address `json:"l2Contract"`     // The TaikoL2 contract called by the anchor transaction
+	Treasury       common.Address `json:"treasury"`       // The protocol treasury
+	AnchorGasLimit uint64         `json:"anchorGasLimit"` // The gas limit of the anchor transaction
+
+	// AnchorMethod is the signature of the TaikoL2 method called by the anchor
+	// transaction before Ontake, which differs between the TaikoL2 versions the
+	// networks were deployed with. Defaults to DefaultAnchorMethod.
+	AnchorMethod string `json:"anchorMethod,omitempty"`
+
+	// BaseFeeDestination receives the L2 base fee, which is not burnt, defaults
+	// to the treasury.
+	BaseFeeDestination *common.Address `json:"baseFeeDestination,omitempty"`
+
+	// BlockMaxTxListBytes is the maximum size of a proposed block's encoded txList,
+	// only the anchor transaction of a larger txList is kept. Zero means no limit.
+	BlockMaxTxListBytes uint64 `json:"blockMaxTxListBytes,omitempty"`
+
+	// BlockMaxTransactions is the maximum number of transactions following the
+	// anchor transaction in a proposed block's txList, the extra transactions are
+	// dropped. Zero means no limit.
+	BlockMaxTransactions uint64 `json:"blockMaxTransactions,omitempty"`
+
+	// BaseFeeConfig is the parameters the L2 base fee of a block is derived with,
+	// the base fee of the blocks is only verified if they are given.
+	BaseFeeConfig *TaikoBaseFeeConfig `json:"baseFeeConfig,omitempty"`
+}
+
+// TaikoBaseFeeConfig is the parameters the L2 base fee of a block is derived with
+// by the TaikoL2 contract, from the gas excess it stores with the parent block,
+// like Ethereum's EIP-4844 blob base fee: the base fee grows exponentially with
+// the gas used by the blocks in excess of the gas issued to them over time.
+type TaikoBaseFeeConfig struct {
+	ActivationBlock        *big.Int `json:"activationBlock"`                  // First block whose base fee is verified
+	InitialGasExcess       uint64   `json:"initialGasExcess"`                 // Gas excess stored with the parent of the activation block (never zero)
+	AdjustmentQuotient     uint64   `json:"adjustmentQuotient"`               // Gas target multiplier, bounds the base fee change per gas excess
+	GasIssuancePerSecond   uint64   `json:"gasIssuancePerSecond"`             // Gas issued to the blocks for each second since their parent
+	MinGasExcess           uint64   `json:"minGasExcess"`                     // Lower bound of the gas excess, sets the minimum base fee
+	MaxGasIssuancePerBlock uint64   `json:"maxGasIssuancePerBlock,omitempty"` // Upper bound of the gas issued to a block (0 = no bound)
+}
+
+// IsActive returns whether the base fee of the given block is verified.
+func (c *TaikoBaseFeeConfig) IsActive(num *big.Int) bool {
+	return c != nil && isBlockForked(c.ActivationBlock, num)
+}
+
+// GasTarget returns the gas target of the base fee exponential, the gas excess
+// which multiplies the base fee by e.
+func (c *TaikoBaseFeeConfig) GasTarget() uint64 {
+	return c.GasIssuancePerSecond * c.AdjustmentQuotient
+}
+
+// GasIssuance returns the gas issued to a block produced the given number of
+// seconds after its parent.
+func (c *TaikoBaseFeeConfig) GasIssuance(blockTime uint64) *big.Int {
+	issuance := new(big.Int).Mul(new(big.Int).SetUint64(blockTime), new(big.Int).SetUint64(c.GasIssuancePerSecond))
+	if c.MaxGasIssuancePerBlock != 0 && issuance.Cmp(new(big.Int).SetUint64(c.MaxGasIssuancePerBlock)) > 0 {
+		issuance.SetUint64(c.MaxGasIssuancePerBlock)
+	}
+	return issuance
+}
+
+// String implements the stringer interface, returning the base fee parameters.
+func (c *TaikoBaseFeeConfig) String() string {
+	if c == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{activationBlock: %v, initialGasExcess: %d, adjustmentQuotient: %d, gasIssuancePerSecond: %d, minGasExcess: %d, maxGasIssuancePerBlock: %d}",
+		c.ActivationBlock, c.InitialGasExcess, c.AdjustmentQuotient, c.GasIssuancePerSecond, c.MinGasExcess, c.MaxGasIssuancePerBlock)
+}
+
+// check checks that a base fee can be derived with the given parameters, the
+// TaikoL2 contract holds the adjustment quotient in an uint8 and the gas issuance
+// parameters in uint32s. The activation block and the gas excess stored with its
+// parent are required, the TaikoL2 contract never stores a zero gas excess.
+func (c *TaikoBaseFeeConfig) check() error {
+	if c == nil {
+		return nil
+	}
+	if c.ActivationBlock == nil || c.InitialGasExcess == 0 {
+		return errors.New("invalid taiko base fee config: missing activationBlock or initialGasExcess")
+	}
+	if c.AdjustmentQuotient == 0 || c.GasIssuancePerSecond == 0 {
+		return errors.New("invalid taiko base fee config: zero adjustmentQuotient or gasIssuancePerSecond")
+	}
+	if c.AdjustmentQuotient > math.MaxUint8 {
+		return fmt.Errorf("invalid taiko base fee config: adjustmentQuotient %d above %d", c.AdjustmentQuotient, math.MaxUint8)
+	}
+	if c.GasIssuancePerSecond > math.MaxUint32 || c.MaxGasIssuancePerBlock > math.MaxUint32 {
+		return fmt.Errorf("invalid taiko base fee config: gas issuance above %d", uint64(math.MaxUint32))
+	}
+	return nil
+}
+
+// taikoBaseFeeActivationBlock returns the first block whose base fee is verified,
+// or nil if the base fees are never verified.
+func (c *ChainConfig) taikoBaseFeeActivationBlock() *big.Int {
+	if !c.Taiko || c.TaikoParams().BaseFeeConfig == nil {
+		return nil
+	}
+	return c.TaikoParams().BaseFeeConfig.ActivationBlock
+}
+
+// AnchorMethodSignature returns the signature of the TaikoL2 method called by the
+// anchor transaction before Ontake.
+func (c *TaikoConfig) AnchorMethodSignature() string {
+	if c.AnchorMethod == "" {
+		return DefaultAnchorMethod
+	}
+	return c.AnchorMethod
+}
+
+// AnchorCalldataLength returns the length of the ABI encoded calldata of the
+// given TaikoL2 method, which must only have static arguments: the selector
+// followed by a 32 bytes word for each elementary argument.
+func AnchorCalldataLength(signature string) (int, error) {
+	open := strings.IndexByte(signature, '(')
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return 0, fmt.Errorf("invalid method signature %q", signature)
+	}
+	words, err := staticArgsWords(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid method signature %q: %w", signature, err)
+	}
+	return 4 + 32*words, nil
+}
+
+// staticArgsWords returns the number of 32 bytes words the given comma separated
+// static ABI types are encoded in, tuples being encoded in place.
+func staticArgsWords(args string) (int, error) {
+	if args == "" {
+		return 0, nil
+	}
+	var (
+		words int
+		depth int
+		start int
+	)
+	for i := 0; i <= len(args); i++ {
+		if i < len(args) {
+			switch args[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth < 0 {
+				return 0, errors.New("unbalanced parentheses")
+			}
+			if args[i] != ',' || depth > 0 {
+				continue
+			}
+		}
+		arg := args[start:i]
+		start = i + 1
+
+		if strings.HasPrefix(arg, "(") && strings.HasSuffix(arg, ")") {
+			n, err := staticArgsWords(arg[1 : len(arg)-1])
+			if err != nil {
+				return 0, err
+			}
+			words += n
+		} else if isStaticElementaryType(arg) {
+			words++
+		} else {
+			return 0, fmt.Errorf("unsupported argument type %q", arg)
+		}
+	}
+	if depth != 0 {
+		return 0, errors.New("unbalanced parentheses")
+	}
+	return words, nil
+}
+
+// isStaticElementaryType returns whether the given ABI type is an elementary
+// type encoded in a single 32 bytes word.
+func isStaticElementaryType(typ string) bool {
+	if typ == "bool" || typ == "address" {
+		return true
+	}
+	for _, prefix := range []string{"uint", "int", "bytes"} {
+		if !strings.HasPrefix(typ, prefix) {
+			continue
+		}
+		size, err := strconv.Atoi(typ[len(prefix):])
+		if err != nil {
+			return false
+		}
+		if prefix == "bytes" {
+			return size >= 1 && size <= 32
+		}
+		return size >= 8 && size <= 256 && size%8 == 0
+	}
+	return false
+}
+
+// check checks that the anchor transaction can be verified, and that a base fee
+// can be derived with the given protocol settings.
+func (c *TaikoConfig) check() error {
+	if _, err := AnchorCalldataLength(c.AnchorMethodSignature()); err != nil {
+		return fmt.Errorf("invalid taiko anchor method: %w", err)
+	}
+	return c.BaseFeeConfig.check()
+}
+
+// BaseFeeRecipient returns the account receiving the L2 base fee.
+func (c *TaikoConfig) BaseFeeRecipient() common.Address {
+	if c.BaseFeeDestination != nil {
+		return *c.BaseFeeDestination
+	}
+	return c.Treasury
+}
+
+// String implements the stringer interface, returning the protocol settings.
+func (c *TaikoConfig) String() string {
+	return fmt.Sprintf(
+		"anchorSender: %s, l2Contract: %s, treasury: %s, anchorGasLimit: %d, anchorMethod: %s, baseFeeRecipient: %s, blockMaxTxListBytes: %d, blockMaxTransactions: %d, baseFeeConfig: %s",
+		c.AnchorSender, c.L2Contract, c.Treasury, c.AnchorGasLimit, c.AnchorMethodSignature(), c.BaseFeeRecipient(), c.BlockMaxTxListBytes, c.BlockMaxTransactions, c.BaseFeeConfig,
+	)
+}
+
+// TaikoParams returns the Taiko protocol settings of the chain, or the default ones
+// if the chain config doesn't specify them.
+func (c *ChainConfig) TaikoParams() *TaikoConfig {
+	if c.TaikoConfig != nil {
+		return c.TaikoConfig
+	}
+	return DefaultTaikoConfig
+}
+
+// UnmarshalJSON decodes a chain config, mapping the legacy top-level `treasury`
+// key of the configs written before the `taikoConfig` section was introduced
+// into the Taiko protocol settings.
+func (c *ChainConfig) UnmarshalJSON(input []byte) error {
+	type chainConfig ChainConfig
+	var dec struct {
+		*chainConfig
+		Treasury *common.Address `json:"treasury"`
+	}
+	dec.chainConfig = (*chainConfig)(c)
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	// The legacy key was always encoded, zero when not set.
+	if !c.Taiko || dec.Treasury == nil || *dec.Treasury == (common.Address{}) {
+		return nil
+	}
+	if c.TaikoConfig == nil {
+		taikoConfig := *DefaultTaikoConfig
+		c.TaikoConfig = &taikoConfig
+		c.TaikoConfig.Treasury = *dec.Treasury
+	} else if c.TaikoConfig.Treasury == (common.Address{}) {
+		c.TaikoConfig.Treasury = *dec.Treasury
+	}
+	return nil
+}
+
+// IsOntake returns whether num is either equal to the Ontake fork block or greater.
+//
+// Ontake replaces the TaikoL2.anchor transaction by TaikoL2.anchorV2, and shares a
+// part of the L2 base fee with the block proposer.
+func (c *ChainConfig) IsOntake(num *big.Int) bool {
+	return c.Taiko && isBlockForked(c.OntakeBlock, num)
+}
+
+// checkTaikoForkOrder checks that the Taiko hard forks are only scheduled on Taiko
+// networks.
+func (c *ChainConfig) checkTaikoForkOrder() error {
+	if !c.Taiko && c.OntakeBlock != nil {
+		return errors.New("taiko hard forks scheduled on a non-taiko network")
+	}
+	return nil
+}
+
 // Network IDs
 var (
 	TaikoMainnetNetworkID   = big.NewInt(167)
@@ -36,5 +361,10 @@ var TaikoChainConfig = &ChainConfig{
 	TerminalTotalDifficulty:       common.Big0,
 	TerminalTotalDifficultyPassed: true,
 	Taiko:                         true,
-	Treasury:                      common.HexToAddress("0xdf09A0afD09a63fb04ab3573922437e1e637dE8b"),
+	TaikoConfig: &TaikoConfig{
+		AnchorSender:   GoldenTouchAccount,
+		L2Contract:     TaikoL2Address,
+		Treasury:       TaikoTreasury,
+		AnchorGasLimit: AnchorGasLimit,
+	},
 }
@@ -0,0 +1,188 @@
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaikoConfigJSON(t *testing.T) {
+	var config ChainConfig
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"chainId": 167,
+		"taiko": true,
+		"taikoConfig": {
+			"anchorSender": "0x0000000000000000000000000000000000000001",
+			"l2Contract": "0x0000000000000000000000000000000000000002",
+			"treasury": "0x0000000000000000000000000000000000000003",
+			"anchorGasLimit": 180000
+		}
+	}`), &config))
+
+	taikoConfig := config.TaikoParams()
+	require.Equal(t, common.HexToAddress("0x01"), taikoConfig.AnchorSender)
+	require.Equal(t, common.HexToAddress("0x02"), taikoConfig.L2Contract)
+	require.Equal(t, common.HexToAddress("0x03"), taikoConfig.Treasury)
+	require.Equal(t, uint64(180000), taikoConfig.AnchorGasLimit)
+
+	// The base fee is sent to the treasury by default.
+	require.Equal(t, taikoConfig.Treasury, taikoConfig.BaseFeeRecipient())
+
+	destination := common.HexToAddress("0x04")
+	taikoConfig.BaseFeeDestination = &destination
+	require.Equal(t, destination, taikoConfig.BaseFeeRecipient())
+
+	// Chain configs without a `taikoConfig` section use the default settings.
+	require.Equal(t, DefaultTaikoConfig, (&ChainConfig{Taiko: true}).TaikoParams())
+}
+
+func TestTaikoConfigLegacyTreasuryJSON(t *testing.T) {
+	// Configs written before the `taikoConfig` section had a top-level treasury.
+	var config ChainConfig
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"chainId": 167,
+		"taiko": true,
+		"treasury": "0x0000000000000000000000000000000000000003"
+	}`), &config))
+
+	taikoConfig := config.TaikoParams()
+	require.Equal(t, common.HexToAddress("0x03"), taikoConfig.Treasury)
+	require.Equal(t, DefaultTaikoConfig.AnchorSender, taikoConfig.AnchorSender)
+	require.Equal(t, DefaultTaikoConfig.L2Contract, taikoConfig.L2Contract)
+	require.Equal(t, TaikoTreasury, DefaultTaikoConfig.Treasury)
+
+	// The legacy key completes a `taikoConfig` section without treasury.
+	config = ChainConfig{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"taiko": true,
+		"treasury": "0x0000000000000000000000000000000000000003",
+		"taikoConfig": {"anchorSender": "0x0000000000000000000000000000000000000001"}
+	}`), &config))
+	require.Equal(t, common.HexToAddress("0x01"), config.TaikoParams().AnchorSender)
+	require.Equal(t, common.HexToAddress("0x03"), config.TaikoParams().Treasury)
+
+	// But it doesn't override the treasury of the section.
+	config = ChainConfig{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"taiko": true,
+		"treasury": "0x0000000000000000000000000000000000000003",
+		"taikoConfig": {"treasury": "0x0000000000000000000000000000000000000004"}
+	}`), &config))
+	require.Equal(t, common.HexToAddress("0x04"), config.TaikoParams().Treasury)
+
+	// The zero treasury encoded by default doesn't replace the default settings.
+	config = ChainConfig{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"taiko": true,
+		"treasury": "0x0000000000000000000000000000000000000000"
+	}`), &config))
+	require.Nil(t, config.TaikoConfig)
+	require.Equal(t, DefaultTaikoConfig, config.TaikoParams())
+}
+
+func TestTaikoHardforks(t *testing.T) {
+	config := &ChainConfig{
+		Taiko:       true,
+		OntakeBlock: big.NewInt(10),
+	}
+	require.Nil(t, config.checkTaikoForkOrder())
+
+	require.False(t, config.IsOntake(big.NewInt(9)))
+	require.True(t, config.IsOntake(big.NewInt(10)))
+
+	rules := config.Rules(big.NewInt(10), true, 2000)
+	require.True(t, rules.IsOntake)
+
+	// Taiko hard forks are rejected on non Taiko networks.
+	nonTaiko := &ChainConfig{OntakeBlock: big.NewInt(10)}
+	require.NotNil(t, nonTaiko.checkTaikoForkOrder())
+	require.False(t, nonTaiko.IsOntake(big.NewInt(10)))
+}
+
+func TestTaikoBaseFeeConfig(t *testing.T) {
+	config := &TaikoBaseFeeConfig{
+		ActivationBlock:        big.NewInt(10),
+		InitialGasExcess:       1_340_000_000,
+		AdjustmentQuotient:     8,
+		GasIssuancePerSecond:   5_000_000,
+		MinGasExcess:           1_340_000_000,
+		MaxGasIssuancePerBlock: 600_000_000,
+	}
+	require.Nil(t, config.check())
+	require.False(t, config.IsActive(big.NewInt(9)))
+	require.True(t, config.IsActive(big.NewInt(10)))
+
+	require.Equal(t, uint64(40_000_000), config.GasTarget())
+	require.Equal(t, big.NewInt(60_000_000), config.GasIssuance(12))
+	require.Equal(t, big.NewInt(600_000_000), config.GasIssuance(1000))
+	config.MaxGasIssuancePerBlock = 0
+	require.Equal(t, big.NewInt(5_000_000_000), config.GasIssuance(1000))
+
+	// The activation block and the initial gas excess are required.
+	config.ActivationBlock = common.Big0
+	require.Nil(t, config.check())
+	require.True(t, config.IsActive(common.Big0))
+	config.InitialGasExcess = 0
+	require.NotNil(t, config.check())
+	config.ActivationBlock, config.InitialGasExcess = nil, 1_340_000_000
+	require.NotNil(t, config.check())
+	require.False(t, config.IsActive(big.NewInt(10)))
+
+	// Without base fee parameters, base fees are not verified.
+	require.Nil(t, (*TaikoBaseFeeConfig)(nil).check())
+	require.False(t, (*TaikoBaseFeeConfig)(nil).IsActive(big.NewInt(10)))
+
+	require.NotNil(t, (&TaikoBaseFeeConfig{ActivationBlock: common.Big0, InitialGasExcess: 1, GasIssuancePerSecond: 5_000_000}).check())
+	require.NotNil(t, (&TaikoBaseFeeConfig{ActivationBlock: common.Big0, InitialGasExcess: 1, AdjustmentQuotient: 8}).check())
+	require.NotNil(t, (&TaikoBaseFeeConfig{ActivationBlock: common.Big0, InitialGasExcess: 1, AdjustmentQuotient: 256, GasIssuancePerSecond: 5_000_000}).check())
+	require.NotNil(t, (&TaikoBaseFeeConfig{ActivationBlock: common.Big0, InitialGasExcess: 1, AdjustmentQuotient: 8, GasIssuancePerSecond: 1 << 32}).check())
+}
+
+func TestTaikoBaseFeeCompatible(t *testing.T) {
+	newConfig := func(activation *big.Int) *ChainConfig {
+		return &ChainConfig{Taiko: true, TaikoConfig: &TaikoConfig{
+			BaseFeeConfig: &TaikoBaseFeeConfig{ActivationBlock: activation, AdjustmentQuotient: 8, GasIssuancePerSecond: 5_000_000},
+		}}
+	}
+	stored, head := newConfig(big.NewInt(10)), uint64(20)
+
+	// The activation can't be moved once the chain went past it.
+	require.NotNil(t, stored.CheckCompatible(newConfig(big.NewInt(15)), head, 0))
+	require.NotNil(t, stored.CheckCompatible(newConfig(nil), head, 0))
+	require.NotNil(t, stored.CheckCompatible(&ChainConfig{Taiko: true}, head, 0))
+	require.Nil(t, stored.CheckCompatible(newConfig(big.NewInt(10)), head, 0))
+
+	// But it can be scheduled ahead of the head.
+	require.Nil(t, (&ChainConfig{Taiko: true}).CheckCompatible(newConfig(big.NewInt(30)), head, 0))
+	err := stored.CheckCompatible(newConfig(big.NewInt(15)), 12, 0)
+	require.NotNil(t, err)
+	require.Equal(t, uint64(9), err.RewindToBlock)
+}
+
+func TestAnchorCalldataLength(t *testing.T) {
+	for signature, length := range map[string]int{
+		"anchor()":                              4,
+		"anchor(uint256,bytes32)":               4 + 2*32,
+		"anchor(bytes32,bytes32,uint64,uint32)": 4 + 4*32,
+		"anchor(bytes32,bytes32,uint64,uint64)": 4 + 4*32,
+		"anchorV2(uint64,bytes32,uint32,(uint8,uint8,uint32,uint64,uint32))": 4 + 8*32,
+	} {
+		have, err := AnchorCalldataLength(signature)
+		require.Nil(t, err, signature)
+		require.Equal(t, length, have, signature)
+	}
+	for _, signature := range []string{
+		"", "anchor", "(uint256)", "anchor(bytes)", "anchor(uint256[])", "anchor(uint7)", "anchor(bytes33)", "anchor((uint8)", "anchor(uint8))",
+	} {
+		_, err := AnchorCalldataLength(signature)
+		require.NotNil(t, err, signature)
+	}
+
+	// Invalid anchor methods are rejected with the chain config.
+	config := &ChainConfig{Taiko: true, TaikoConfig: &TaikoConfig{AnchorMethod: "anchor(bytes)"}}
+	require.NotNil(t, config.TaikoParams().check())
+	require.Equal(t, DefaultAnchorMethod, DefaultTaikoConfig.AnchorMethodSignature())
+}
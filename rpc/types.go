@@ -63,6 +63,9 @@ type jsonWriter interface {
 type BlockNumber int64
 
 const (
+	// CHANGE(taiko): the newest L2 block built ahead of its inclusion on L1.
+	PreconfirmedBlockNumber = BlockNumber(-5)
+
 	SafeBlockNumber      = BlockNumber(-4)
 	FinalizedBlockNumber = BlockNumber(-3)
 	PendingBlockNumber   = BlockNumber(-2)
@@ -71,7 +74,7 @@ const (
 )
 
 // UnmarshalJSON parses the given JSON fragment into a BlockNumber. It supports:
-// - "safe", "finalized", "latest", "earliest" or "pending" as string arguments
+// - "safe", "finalized", "latest", "earliest", "pending" or "preconfirmed" as string arguments
 // - the block number
 // Returned errors:
 // - an invalid block number error when the given argument isn't a known strings
@@ -98,6 +101,9 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 	case "safe":
 		*bn = SafeBlockNumber
 		return nil
+	case "preconfirmed":
+		*bn = PreconfirmedBlockNumber
+		return nil
 	}
 
 	blckNum, err := hexutil.DecodeUint64(input)
@@ -112,7 +118,7 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 }
 
 // MarshalText implements encoding.TextMarshaler. It marshals:
-// - "safe", "finalized", "latest", "earliest" or "pending" as strings
+// - "safe", "finalized", "latest", "earliest", "pending" or "preconfirmed" as strings
 // - other numbers as hex
 func (bn BlockNumber) MarshalText() ([]byte, error) {
 	switch bn {
@@ -126,6 +132,8 @@ func (bn BlockNumber) MarshalText() ([]byte, error) {
 		return []byte("finalized"), nil
 	case SafeBlockNumber:
 		return []byte("safe"), nil
+	case PreconfirmedBlockNumber:
+		return []byte("preconfirmed"), nil
 	default:
 		return hexutil.Uint64(bn).MarshalText()
 	}
@@ -180,6 +188,10 @@ func (bnh *BlockNumberOrHash) UnmarshalJSON(data []byte) error {
 		bn := SafeBlockNumber
 		bnh.BlockNumber = &bn
 		return nil
+	case "preconfirmed":
+		bn := PreconfirmedBlockNumber
+		bnh.BlockNumber = &bn
+		return nil
 	default:
 		if len(input) == 66 {
 			hash := common.Hash{}
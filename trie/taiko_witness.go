@@ -0,0 +1,18 @@
+package trie
+
+// Witness returns the RLP encoded nodes loaded from the database since the trie
+// was opened or last committed, i.e. all the nodes needed to replay the accesses
+// made to the trie so far. The returned blobs must not be modified.
+func (t *Trie) Witness() [][]byte {
+	nodes := make([][]byte, 0, len(t.tracer.accessList))
+	for _, blob := range t.tracer.accessList {
+		nodes = append(nodes, blob)
+	}
+	return nodes
+}
+
+// Witness returns the RLP encoded nodes loaded from the database since the trie
+// was opened or last committed.
+func (t *StateTrie) Witness() [][]byte {
+	return t.trie.Witness()
+}